@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"resumectl/internal/forge"
+)
+
+// DefaultAPIURL is the Bitbucket Cloud API root
+const DefaultAPIURL = "https://api.bitbucket.org/2.0"
+
+// maxPages bounds pagination (100 repositories per page)
+const maxPages = 10
+
+// Source imports projects from Bitbucket Cloud
+type Source struct {
+	client forge.Client
+}
+
+// New creates a Bitbucket source. apiURL defaults to Bitbucket Cloud. token
+// is either an access token or "username:app_password".
+func New(apiURL, token string) *Source {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	s := &Source{client: forge.Client{BaseURL: strings.TrimSuffix(apiURL, "/")}}
+	if token != "" {
+		s.client.Authorize = func(req *http.Request) {
+			if user, password, ok := strings.Cut(token, ":"); ok {
+				req.SetBasicAuth(user, password)
+			} else {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+	}
+	return s
+}
+
+// repository is the subset of the Bitbucket repository API used here
+type repository struct {
+	Name        string    `json:"name"`
+	FullName    string    `json:"full_name"`
+	Description string    `json:"description"`
+	Language    string    `json:"language"`
+	IsPrivate   bool      `json:"is_private"`
+	UpdatedOn   time.Time `json:"updated_on"`
+	Parent      *struct{} `json:"parent"` // Set for forks
+	Links       struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// Name implements forge.ProjectSource
+func (s *Source) Name() string {
+	return "Bitbucket"
+}
+
+// FetchProjects implements forge.ProjectSource. Bitbucket has no stars:
+// the "stars" ranking falls back to the most recently updated repositories.
+// username is a workspace slug.
+func (s *Source) FetchProjects(username string, opts forge.Options) ([]forge.Project, error) {
+	opts.Defaults()
+	switch opts.Rank {
+	case forge.RankStars:
+		opts.Rank = forge.RankRecent
+	case forge.RankRecent:
+	default:
+		return nil, fmt.Errorf("ranking '%s' is not supported for Bitbucket (use recent)", opts.Rank)
+	}
+	if opts.IncludeOrgs {
+		return nil, fmt.Errorf("--include-orgs is not supported for Bitbucket: import each workspace instead")
+	}
+
+	var repos []forge.Repo
+	next := fmt.Sprintf("/repositories/%s?pagelen=100", url.PathEscape(username))
+	for page := 0; next != "" && page < maxPages; page++ {
+		var result struct {
+			Values []repository `json:"values"`
+			Next   string       `json:"next"`
+		}
+		_, err := s.client.Get(next, &result)
+		if err == forge.ErrNotFound {
+			return nil, fmt.Errorf("Bitbucket workspace '%s' not found", username)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range result.Values {
+			if r.IsPrivate {
+				continue
+			}
+			repos = append(repos, forge.Repo{
+				ID:          r.FullName,
+				Name:        r.Name,
+				Description: r.Description,
+				URL:         r.Links.HTML.Href,
+				Language:    displayLanguage(r.Language),
+				Fork:        r.Parent != nil,
+				PushedAt:    r.UpdatedOn,
+			})
+		}
+		next = result.Next
+	}
+
+	if forge.DebugMode {
+		fmt.Printf("[DEBUG] Found %d Bitbucket repositories for workspace %s\n", len(repos), username)
+	}
+
+	return forge.Select(repos, opts)
+}
+
+// displayLanguage capitalizes the lowercase language names of Bitbucket
+func displayLanguage(language string) string {
+	switch language {
+	case "":
+		return ""
+	case "javascript":
+		return "JavaScript"
+	case "typescript":
+		return "TypeScript"
+	case "php", "html", "css", "sql":
+		return strings.ToUpper(language)
+	case "c#":
+		return "C#"
+	case "c++":
+		return "C++"
+	}
+	return strings.ToUpper(language[:1]) + language[1:]
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package bitbucket
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"resumectl/internal/forge"
+)
+
+// newServer starts a fake Bitbucket API where workspace "jane" has two
+// pages of repositories linked by absolute "next" URLs
+func newServer(t *testing.T, authorization string) *httptest.Server {
+	t.Helper()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != authorization {
+			t.Errorf("%s: Authorization = %q, want %q", r.URL.Path, got, authorization)
+		}
+		if r.URL.Path != "/2.0/repositories/jane" {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{"values": [
+				{"name": "tool", "full_name": "jane/tool", "language": "go", "updated_on": "2026-01-10T00:00:00Z", "links": {"html": {"href": "https://bitbucket.org/jane/tool"}}},
+				{"name": "secret", "full_name": "jane/secret", "is_private": true, "updated_on": "2026-09-01T00:00:00Z"},
+				{"name": "fork", "full_name": "jane/fork", "parent": {}, "updated_on": "2026-09-02T00:00:00Z"}
+			], "next": "%s/2.0/repositories/jane?pagelen=100&page=2"}`, srv.URL)
+		case "2":
+			fmt.Fprint(w, `{"values": [
+				{"name": "site", "full_name": "jane/site", "language": "javascript", "updated_on": "2026-08-10T00:00:00Z"}
+			]}`)
+		default:
+			t.Errorf("unexpected page: %s", r.URL)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchProjects(t *testing.T) {
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("jane:app-password"))
+
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+	}{
+		{name: "anonymous"},
+		{name: "access token", token: "secret", authorization: "Bearer secret"},
+		{name: "app password", token: "jane:app-password", authorization: basic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newServer(t, tt.authorization)
+
+			// Bitbucket has no stars: the default ranking is the most recent
+			projects, err := New(srv.URL+"/2.0/", tt.token).FetchProjects("jane", forge.Options{})
+			if err != nil {
+				t.Fatalf("FetchProjects: %v", err)
+			}
+
+			var names []string
+			var technologies [][]string
+			for _, proj := range projects {
+				names = append(names, proj.Name)
+				technologies = append(technologies, proj.Technologies)
+			}
+			if want := []string{"site", "tool"}; !reflect.DeepEqual(names, want) {
+				t.Errorf("projects = %v, want %v", names, want)
+			}
+			if want := [][]string{{"JavaScript"}, {"Go"}}; !reflect.DeepEqual(technologies, want) {
+				t.Errorf("technologies = %v, want %v", technologies, want)
+			}
+		})
+	}
+}
+
+func TestFetchProjectsErrors(t *testing.T) {
+	srv := newServer(t, "")
+	source := New(srv.URL+"/2.0", "")
+
+	for _, tt := range []struct {
+		workspace string
+		opts      forge.Options
+		want      string
+	}{
+		{workspace: "nobody", want: "Bitbucket workspace 'nobody' not found"},
+		{workspace: "jane", opts: forge.Options{IncludeOrgs: true}, want: "--include-orgs is not supported for Bitbucket: import each workspace instead"},
+		{workspace: "jane", opts: forge.Options{Rank: forge.RankCommits}, want: "ranking 'commits' is not supported for Bitbucket (use recent)"},
+	} {
+		if _, err := source.FetchProjects(tt.workspace, tt.opts); err == nil || err.Error() != tt.want {
+			t.Errorf("FetchProjects(%q, %+v): err = %v, want %q", tt.workspace, tt.opts, err, tt.want)
+		}
+	}
+}
+
+func TestDisplayLanguage(t *testing.T) {
+	for language, want := range map[string]string{
+		"":           "",
+		"go":         "Go",
+		"typescript": "TypeScript",
+		"html":       "HTML",
+		"c#":         "C#",
+		"python":     "Python",
+	} {
+		if got := displayLanguage(language); got != want {
+			t.Errorf("displayLanguage(%q) = %q, want %q", language, got, want)
+		}
+	}
+}
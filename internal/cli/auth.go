@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"resumectl/internal/credentials"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var authEncrypt bool
+
+// credentialStore is the credentials file, loaded on first use
+var credentialStore *credentials.Store
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage stored LinkedIn and forge credentials",
+	Long: `Store the LinkedIn session cookie and forge tokens so that init and sync
+use them without passing them on the command line, where they would end up
+in your shell history and be visible to other users with ps.
+
+Secrets are stored in a credentials file only readable by you
+($XDG_CONFIG_HOME/resumectl/credentials.json on Linux), optionally encrypted
+with a passphrase. The passphrase is read from $` + credentials.PassphraseEnv + ` or asked
+for when needed.
+
+Environment variables take precedence over stored secrets:
+  linkedin   $LINKEDIN_COOKIE     gitea      $GITEA_TOKEN
+  github     $GITHUB_TOKEN        forgejo    $FORGEJO_TOKEN
+  gitlab     $GITLAB_TOKEN        bitbucket  $BITBUCKET_TOKEN
+
+Usage examples:
+  resumectl auth login linkedin             # Paste the li_at cookie when asked
+  pass show linkedin | resumectl auth login linkedin  # Read it from stdin
+  GITHUB_TOKEN=ghp_... resumectl auth login github    # Read it from the environment
+  resumectl auth login github --encrypt     # Encrypt the credentials file
+  resumectl auth status                     # List stored credentials
+  resumectl auth logout linkedin            # Remove a stored secret`,
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:       "login <service>",
+	Short:     "Store a secret for a service",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: serviceNames(),
+	Run:       runAuthLogin,
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:       "logout <service>",
+	Short:     "Remove the stored secret of a service",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: serviceNames(),
+	Run:       runAuthLogout,
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "List stored credentials",
+	Args:  cobra.NoArgs,
+	Run:   runAuthStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	authCmd.AddCommand(authStatusCmd)
+	authLoginCmd.Flags().BoolVar(&authEncrypt, "encrypt", false, "Encrypt the credentials file with a passphrase")
+}
+
+// serviceNames returns the services secrets can be stored for, sorted
+func serviceNames() []string {
+	var names []string
+	for service := range credentials.Services {
+		names = append(names, service)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func checkService(service string) {
+	if _, ok := credentials.Services[service]; !ok {
+		log.Fatal("Unknown service", "service", service, "available", strings.Join(serviceNames(), ", "))
+	}
+}
+
+// credential returns the secret of a service: the command-line value if
+// given, otherwise the environment variable, otherwise the stored secret
+func credential(service, flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if value := os.Getenv(credentials.Services[service]); value != "" {
+		return value
+	}
+
+	if credentialStore == nil {
+		store, err := openCredentials(false)
+		if err != nil {
+			log.Warn("Could not read stored credentials", "error", err)
+			store = &credentials.Store{}
+		}
+		credentialStore = store
+	}
+
+	secret := credentialStore.Secrets[service]
+	if secret != "" && DebugMode {
+		fmt.Printf("[DEBUG] Using stored %s credentials: %s\n", service, credentials.Redact(secret))
+	}
+	return secret
+}
+
+// openCredentials loads the credentials file, asking for the passphrase if
+// it is encrypted. With create, a passphrase is also asked for a new file.
+func openCredentials(create bool) (*credentials.Store, error) {
+	path, err := credentials.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := credentials.IsEncrypted(path)
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		store, err := credentials.Load(path, "")
+		if err != nil {
+			return nil, err
+		}
+		if create {
+			store.Passphrase, err = readPassphrase(true)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return store, nil
+	}
+
+	passphrase, err := readPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.Load(path, passphrase)
+}
+
+// readPassphrase reads the passphrase from the environment or the terminal
+func readPassphrase(confirmNew bool) (string, error) {
+	if passphrase := os.Getenv(credentials.PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", credentials.ErrPassphraseRequired
+	}
+
+	passphrase, err := readSecret("Passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("empty passphrase")
+	}
+	if confirmNew {
+		again, err := readSecret("Confirm passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
+
+// readSecret reads a line from the terminal without echoing it
+func readSecret(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	secret, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return strings.TrimSpace(string(secret)), err
+}
+
+func runAuthLogin(cmd *cobra.Command, args []string) {
+	service := strings.ToLower(args[0])
+	checkService(service)
+
+	// Read the secret from the environment, the terminal or piped stdin
+	secret := os.Getenv(credentials.Services[service])
+	if secret == "" {
+		var err error
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			prompt := "Token: "
+			if service == "linkedin" {
+				prompt = "li_at cookie: "
+			}
+			secret, err = readSecret(prompt)
+		} else {
+			var data []byte
+			data, err = io.ReadAll(bufio.NewReader(os.Stdin))
+			secret = strings.TrimSpace(string(data))
+		}
+		if err != nil {
+			log.Fatal("Error reading secret", "error", err)
+		}
+	}
+	if secret == "" {
+		log.Fatal("No secret given", "env", credentials.Services[service])
+	}
+
+	store, err := openCredentials(authEncrypt)
+	if err != nil {
+		log.Fatal("Error opening credentials", "error", err)
+	}
+	store.Secrets[service] = secret
+
+	if err := store.Save(); err != nil {
+		log.Fatal("Error saving credentials", "error", err)
+	}
+	log.Info("Credentials stored", "service", service, "encrypted", store.Passphrase != "", "path", store.Path)
+}
+
+func runAuthLogout(cmd *cobra.Command, args []string) {
+	service := strings.ToLower(args[0])
+	checkService(service)
+
+	store, err := openCredentials(false)
+	if err != nil {
+		log.Fatal("Error opening credentials", "error", err)
+	}
+	if _, ok := store.Secrets[service]; !ok {
+		log.Info("No stored credentials", "service", service)
+		return
+	}
+	delete(store.Secrets, service)
+
+	if err := store.Save(); err != nil {
+		log.Fatal("Error saving credentials", "error", err)
+	}
+	log.Info("Credentials removed", "service", service)
+}
+
+func runAuthStatus(cmd *cobra.Command, args []string) {
+	store, err := openCredentials(false)
+	if err != nil {
+		log.Fatal("Error opening credentials", "error", err)
+	}
+
+	for _, service := range serviceNames() {
+		env := credentials.Services[service]
+		switch {
+		case os.Getenv(env) != "":
+			fmt.Printf("  %-10s  from $%s\n", service, env)
+		case store.Secrets[service] != "":
+			fmt.Printf("  %-10s  stored (%s)\n", service, credentials.Redact(store.Secrets[service]))
+		default:
+			fmt.Printf("  %-10s  -\n", service)
+		}
+	}
+
+	fmt.Println()
+	if store.Passphrase != "" {
+		fmt.Println("  Credentials file (encrypted):", store.Path)
+	} else {
+		fmt.Println("  Credentials file:", store.Path)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"resumectl/internal/credentials"
+)
+
+// storeCredentials writes an encrypted credentials file in a temporary
+// configuration directory
+func storeCredentials(t *testing.T, secrets map[string]string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("AppData", dir)
+
+	path, err := credentials.DefaultPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &credentials.Store{Path: path, Secrets: secrets, Passphrase: "passphrase"}
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	credentialStore = nil
+	t.Cleanup(func() { credentialStore = nil })
+}
+
+func TestCredential(t *testing.T) {
+	storeCredentials(t, map[string]string{"github": "stored-token"})
+	t.Setenv(credentials.PassphraseEnv, "passphrase")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITLAB_TOKEN", "env-token")
+
+	tests := []struct {
+		service string
+		flag    string
+		want    string
+	}{
+		{service: "github", flag: "flag-token", want: "flag-token"},
+		{service: "github", want: "stored-token"},
+		{service: "gitlab", want: "env-token"},
+		{service: "bitbucket", want: ""},
+	}
+	for _, tt := range tests {
+		if got := credential(tt.service, tt.flag); got != tt.want {
+			t.Errorf("credential(%q, %q) = %q, want %q", tt.service, tt.flag, got, tt.want)
+		}
+	}
+}
+
+func TestOpenCredentialsPassphrase(t *testing.T) {
+	storeCredentials(t, map[string]string{"github": "stored-token"})
+
+	// Tests do not run in a terminal: the passphrase can only come from the
+	// environment
+	t.Setenv(credentials.PassphraseEnv, "")
+	if _, err := openCredentials(false); !errors.Is(err, credentials.ErrPassphraseRequired) {
+		t.Errorf("without a passphrase: err = %v, want ErrPassphraseRequired", err)
+	}
+
+	t.Setenv(credentials.PassphraseEnv, "wrong")
+	if _, err := openCredentials(false); !errors.Is(err, credentials.ErrWrongPassphrase) {
+		t.Errorf("with a wrong passphrase: err = %v, want ErrWrongPassphrase", err)
+	}
+
+	t.Setenv(credentials.PassphraseEnv, "passphrase")
+	store, err := openCredentials(false)
+	if err != nil {
+		t.Fatalf("openCredentials: %v", err)
+	}
+	if store.Secrets["github"] != "stored-token" {
+		t.Errorf("Secrets = %v, want the stored token", store.Secrets)
+	}
+}
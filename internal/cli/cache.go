@@ -0,0 +1,222 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"time"
+
+	"resumectl/internal/forge"
+	"resumectl/internal/github"
+	"resumectl/internal/httpcache"
+	"resumectl/internal/linkedin"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	offlineMode  bool
+	noCache      bool
+	clearExpired bool
+	cacheTTLFlag map[string]string
+)
+
+// cacheTTLs are the default freshness lifetimes of cached responses per
+// host, overridden with --cache-ttl. Profiles change rarely, repositories
+// more often.
+var cacheTTLs = map[string]time.Duration{
+	"www.linkedin.com": 24 * time.Hour,
+	"api.github.com":   time.Hour,
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the cache of imported data",
+	Long: `Manage the on-disk cache of LinkedIn and forge API responses used by
+init and sync.
+
+Responses are cached in the user cache directory ($XDG_CACHE_HOME/resumectl/http
+on Linux). They are reused without network access while fresh (24 hours for
+LinkedIn, 1 hour for the forge APIs), then revalidated. Use --cache-ttl to
+change these lifetimes, per host or for all hosts with "*", and --offline
+with init or sync to rely on cached data only.
+
+Requests made with the LinkedIn session cookie to the full profile API are
+never cached: offline imports use the cached public profile page instead.
+
+Usage examples:
+  resumectl cache ls                 # List cached responses
+  resumectl cache clear              # Remove all cached responses
+  resumectl cache clear --expired    # Remove stale responses only
+  resumectl cache ls --cache-ttl www.linkedin.com=168h
+                                     # Consider LinkedIn pages fresh for a week`,
+}
+
+var cacheLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List cached responses",
+	Args:  cobra.NoArgs,
+	Run:   runCacheLs,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove cached responses",
+	Args:  cobra.NoArgs,
+	Run:   runCacheClear,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheLsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheClearCmd.Flags().BoolVar(&clearExpired, "expired", false, "Only remove responses that need revalidation")
+	cacheCmd.PersistentFlags().StringToStringVar(&cacheTTLFlag, "cache-ttl", nil, cacheTTLUsage)
+}
+
+// cacheTTLUsage is the help of the --cache-ttl flag of the cache, init and
+// sync commands
+const cacheTTLUsage = "Freshness lifetime of cached responses: host=duration, or *=duration for all hosts (e.g. www.linkedin.com=72h)"
+
+// newCacheTransport returns the cache transport configured for resumectl
+func newCacheTransport() (*httpcache.Transport, error) {
+	dir, err := httpcache.DefaultDir()
+	if err != nil {
+		return nil, fmt.Errorf("no cache directory: %w", err)
+	}
+
+	transport := &httpcache.Transport{Dir: dir, TTLs: maps.Clone(cacheTTLs), Offline: offlineMode}
+	if value, ok := cacheTTLFlag["*"]; ok {
+		ttl, err := parseCacheTTL("*", value)
+		if err != nil {
+			return nil, err
+		}
+		// Replace the defaults of every host
+		transport.TTL = ttl
+		clear(transport.TTLs)
+	}
+	for host, value := range cacheTTLFlag {
+		if host == "*" {
+			continue
+		}
+		ttl, err := parseCacheTTL(host, value)
+		if err != nil {
+			return nil, err
+		}
+		transport.TTLs[host] = ttl
+	}
+	return transport, nil
+}
+
+// parseCacheTTL parses a --cache-ttl duration
+func parseCacheTTL(host, value string) (time.Duration, error) {
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("invalid --cache-ttl duration for %s: %q", host, value)
+	}
+	return ttl, nil
+}
+
+// setupCache routes the importer HTTP clients through the on-disk cache
+func setupCache() {
+	if noCache {
+		if offlineMode {
+			log.Fatal("--offline needs the cache, it cannot be used with --no-cache")
+		}
+		return
+	}
+
+	transport, err := newCacheTransport()
+	if err != nil {
+		if offlineMode {
+			log.Fatal("Cannot work offline", "error", err)
+		}
+		log.Warn("Cache disabled", "error", err)
+		return
+	}
+
+	httpcache.DebugMode = DebugMode
+	for _, client := range []*http.Client{linkedin.HTTPClient, github.HTTPClient, forge.HTTPClient} {
+		client.Transport = transport
+	}
+	if offlineMode {
+		log.Info("Offline mode: using cached data only")
+	}
+}
+
+func runCacheLs(cmd *cobra.Command, args []string) {
+	transport, err := newCacheTransport()
+	if err != nil {
+		log.Fatal("Error setting up cache", "error", err)
+	}
+
+	entries, err := httpcache.List(transport.Dir)
+	if err != nil {
+		log.Fatal("Error reading cache", "error", err)
+	}
+	if len(entries) == 0 {
+		fmt.Println("The cache is empty:", transport.Dir)
+		return
+	}
+
+	var total int
+	for _, e := range entries {
+		state := "fresh"
+		if !transport.Fresh(&e) {
+			state = "stale"
+		}
+		fmt.Printf("  %-5s  %8s  %9s ago  %s\n", state, formatSize(len(e.Body)),
+			time.Since(e.StoredAt).Round(time.Second), e.URL)
+		total += len(e.Body)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %d responses, %s in %s\n", len(entries), formatSize(total), transport.Dir)
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) {
+	transport, err := newCacheTransport()
+	if err != nil {
+		log.Fatal("Error setting up cache", "error", err)
+	}
+
+	var remove func(httpcache.Entry) bool
+	if clearExpired {
+		remove = func(e httpcache.Entry) bool {
+			return !transport.Fresh(&e)
+		}
+	}
+
+	removed, err := httpcache.Clear(transport.Dir, remove)
+	if err != nil {
+		log.Fatal("Error clearing cache", "error", err)
+	}
+	log.Info("Cache cleared", "removed", removed)
+}
+
+// formatSize formats a byte count for display
+func formatSize(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
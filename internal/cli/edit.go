@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"resumectl/internal/editor"
+	"resumectl/internal/merge"
+	"resumectl/internal/models"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit a CV in a full-screen terminal editor",
+	Long: `Open the CV YAML file in a full-screen terminal editor.
+
+Sections are shown as a tree next to a live preview of the CV. Values are
+edited in place and list entries (experiences, highlights, skills...) can be
+reordered. Comments and key order of the file are preserved on save.
+
+Keys:
+  ↑/↓ or k/j        Move
+  ←/→ or h/l        Collapse / expand a section
+  enter             Edit a value, or fold a section
+  K/J or shift+↑/↓  Move a list entry up / down
+  pgup/pgdn         Scroll the preview
+  s                 Save
+  q                 Quit
+
+Usage examples:
+  resumectl edit                     # Edit cv.yaml
+  resumectl edit -d my-cv.yaml       # Edit another file`,
+	Args: cobra.NoArgs,
+	Run:  runEdit,
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+}
+
+func runEdit(cmd *cobra.Command, args []string) {
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		log.Fatal("The editor needs a terminal")
+	}
+
+	original, err := os.ReadFile(dataPath)
+	if err != nil {
+		log.Fatal("Error reading CV file", "path", dataPath, "error", err)
+	}
+
+	preamble, body := merge.SplitPreamble(original)
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		log.Fatal("Error parsing CV file", "path", dataPath, "error", err)
+	}
+
+	style := "light"
+	if lipgloss.HasDarkBackground() {
+		style = "dark"
+	}
+
+	err = editor.Run(&doc, editor.Options{
+		Title:  filepath.Base(dataPath),
+		Render: previewRenderer(style),
+		Save: func(doc *yaml.Node) error {
+			encoded, err := merge.Encode(doc, merge.DetectIndent(body))
+			if err != nil {
+				return err
+			}
+			updated := append(append([]byte{}, preamble...), merge.RestoreBlankLines(body, encoded)...)
+			return writeFileAtomic(dataPath, updated)
+		},
+	})
+	if err != nil {
+		log.Fatal("Error running editor", "error", err)
+	}
+}
+
+// previewRenderer renders a CV document as the Markdown of "show"
+func previewRenderer(style string) func(doc *yaml.Node, width int) (string, error) {
+	renderers := make(map[int]*glamour.TermRenderer)
+
+	return func(doc *yaml.Node, width int) (string, error) {
+		var cv models.CV
+		if err := doc.Decode(&cv); err != nil {
+			return "", fmt.Errorf("invalid CV: %w", err)
+		}
+
+		renderer, ok := renderers[width]
+		if !ok {
+			var err error
+			renderer, err = glamour.NewTermRenderer(
+				glamour.WithStandardStyle(style),
+				glamour.WithWordWrap(width-2),
+			)
+			if err != nil {
+				return "", err
+			}
+			renderers[width] = renderer
+		}
+
+		return renderer.Render(generateMarkdown(&cv))
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"resumectl/internal/bitbucket"
+	"resumectl/internal/forge"
+	"resumectl/internal/gitea"
+	"resumectl/internal/github"
+	"resumectl/internal/gitlab"
+	"resumectl/internal/models"
+	"resumectl/internal/skills"
+
+	"github.com/charmbracelet/log"
+)
+
+var (
+	gitlabUsername string
+	gitlabURL      string
+	gitlabToken    string
+	forgeSpecs     []string
+	deriveSkills   bool
+)
+
+// forgeKinds lists the values accepted before the colon of --forge
+var forgeKinds = []string{"github", "gitlab", "gitea", "forgejo", "bitbucket"}
+
+// hasImports reports whether any LinkedIn or project import was requested
+func hasImports() bool {
+	return linkedinURL != "" || githubUsername != "" || gitlabUsername != "" || len(forgeSpecs) > 0
+}
+
+// fetchForgeImports fetches the projects requested with --gitlab and --forge
+func fetchForgeImports() ([]forge.Project, error) {
+	var projects []forge.Project
+
+	if gitlabUsername != "" {
+		imported, err := fetchForgeProjects(gitlab.New(gitlabURL, credential("gitlab", gitlabToken)), gitlabUsername)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, imported...)
+	}
+
+	for _, spec := range forgeSpecs {
+		source, username, err := parseForgeSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		imported, err := fetchForgeProjects(source, username)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, imported...)
+	}
+
+	return projects, nil
+}
+
+// parseForgeSpec parses a --forge value: "kind:user" for the public instance
+// of a forge, or "kind:https://host/user" for a self-hosted one, possibly
+// served under a path ("kind:https://host/git/user")
+func parseForgeSpec(spec string) (forge.ProjectSource, string, error) {
+	kind, target, ok := strings.Cut(spec, ":")
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if !ok || target == "" {
+		return nil, "", fmt.Errorf("invalid --forge value '%s' (expected kind:user or kind:https://host/user)", spec)
+	}
+
+	baseURL, username := "", strings.Trim(target, "/")
+	if strings.Contains(target, "://") {
+		u, err := url.Parse(target)
+		if err != nil || u.Host == "" {
+			return nil, "", fmt.Errorf("invalid --forge URL '%s'", target)
+		}
+		// The username is the last path segment, the rest is the instance root
+		root, name := path.Split(strings.Trim(u.Path, "/"))
+		if name == "" {
+			return nil, "", fmt.Errorf("no username in --forge URL '%s'", target)
+		}
+		username = name
+		baseURL = strings.TrimSuffix(u.Scheme+"://"+u.Host+"/"+root, "/")
+	}
+
+	switch kind {
+	case "github":
+		github.DebugMode = DebugMode
+		return github.Source{BaseURL: baseURL, Token: credential("github", githubToken)}, username, nil
+	case "gitlab":
+		return gitlab.New(baseURL, credential("gitlab", gitlabToken)), username, nil
+	case "gitea":
+		if baseURL == "" {
+			baseURL = "https://gitea.com"
+		}
+		return gitea.New("Gitea", baseURL, credential("gitea", "")), username, nil
+	case "forgejo":
+		if baseURL == "" {
+			baseURL = "https://codeberg.org"
+		}
+		return gitea.New("Forgejo", baseURL, credential("forgejo", "")), username, nil
+	case "bitbucket":
+		// Bitbucket Cloud only: the web URL does not give the API root
+		return bitbucket.New("", credential("bitbucket", "")), username, nil
+	}
+
+	return nil, "", fmt.Errorf("unknown forge '%s' (available: %s)", kind, strings.Join(forgeKinds, ", "))
+}
+
+// fetchForgeProjects fetches the top projects of a user on a forge
+func fetchForgeProjects(source forge.ProjectSource, username string) ([]forge.Project, error) {
+	log.Info("Fetching "+source.Name()+" projects...", "username", username)
+
+	forge.DebugMode = DebugMode
+	projects, err := source.FetchProjects(username, forge.Options{
+		Count:       githubProjects,
+		Rank:        githubRank,
+		Include:     githubInclude,
+		Exclude:     githubExclude,
+		IncludeOrgs: githubOrgs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source.Name(), err)
+	}
+	log.Info(source.Name()+" projects fetched successfully!", "count", len(projects))
+
+	return projects, nil
+}
+
+// importSkills derives skills from the imported projects and adds the
+// missing ones to cv
+func importSkills(cv *models.CV, projects []forge.Project) {
+	if !deriveSkills || len(projects) == 0 {
+		return
+	}
+
+	derived := skills.Derive(projects)
+	for _, category := range derived {
+		log.Info("Skills derived from repositories", "category", category.Category, "skills", strings.Join(category.Items, ", "))
+	}
+	cv.Skills = skills.Merge(cv.Skills, derived)
+}
+
+// toCVProjects converts imported projects to the CV format
+func toCVProjects(projects []forge.Project) []models.Project {
+	var cvProjects []models.Project
+	for _, proj := range projects {
+		cvProjects = append(cvProjects, models.Project{
+			Name:         proj.Name,
+			Description:  proj.Description,
+			URL:          proj.URL,
+			Technologies: proj.Technologies,
+			Stars:        proj.Stars,
+			Forks:        proj.Forks,
+		})
+	}
+	return cvProjects
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"resumectl/internal/credentials"
+	"resumectl/internal/forge"
+)
+
+func TestParseForgeSpec(t *testing.T) {
+	// No stored or environment token gets in the way
+	credentialStore = &credentials.Store{}
+	t.Cleanup(func() { credentialStore = nil })
+	for _, env := range credentials.Services {
+		t.Setenv(env, "")
+	}
+
+	var requested string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requested == "" {
+			requested = r.URL.Path
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		spec     string
+		name     string // Source name
+		username string
+		request  string // First API request of a self-hosted source
+		err      string
+	}{
+		{spec: "gitlab:jane", name: "GitLab", username: "jane"},
+		{spec: " GitLab :jane/", name: "GitLab", username: "jane"},
+		{spec: "forgejo:jane", name: "Forgejo", username: "jane"},
+		{spec: "bitbucket:jane", name: "Bitbucket", username: "jane"},
+		{spec: "gitlab:" + srv.URL + "/jane/", name: "GitLab", username: "jane", request: "/api/v4/users"},
+		{spec: "gitlab:" + srv.URL + "/git/lab/jane", name: "GitLab", username: "jane", request: "/git/lab/api/v4/users"},
+		{spec: "gitea:" + srv.URL + "/jane", name: "Gitea", username: "jane", request: "/api/v1/users/jane/repos"},
+		{spec: "forgejo:" + srv.URL + "/forgejo/jane", name: "Forgejo", username: "jane", request: "/forgejo/api/v1/users/jane/repos"},
+		{spec: "github:" + srv.URL + "/jane", name: "GitHub", username: "jane", request: "/api/v3/users/jane/repos"},
+		{spec: "gitlab", err: "invalid --forge value 'gitlab' (expected kind:user or kind:https://host/user)"},
+		{spec: "gitlab:", err: "invalid --forge value 'gitlab:' (expected kind:user or kind:https://host/user)"},
+		{spec: "gitea:https:///jane", err: "invalid --forge URL 'https:///jane'"},
+		{spec: "gitea:https://git.example.com/", err: "no username in --forge URL 'https://git.example.com/'"},
+		{spec: "svn:jane", err: "unknown forge 'svn' (available: github, gitlab, gitea, forgejo, bitbucket)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			source, username, err := parseForgeSpec(tt.spec)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseForgeSpec: %v", err)
+			}
+			if source.Name() != tt.name || username != tt.username {
+				t.Errorf("parseForgeSpec = %s, %q, want %s, %q", source.Name(), username, tt.name, tt.username)
+			}
+
+			if tt.request == "" {
+				return
+			}
+			requested = ""
+			if _, err := source.FetchProjects(username, forge.Options{}); err == nil {
+				t.Error("FetchProjects: want an error from the empty server")
+			}
+			if requested != tt.request {
+				t.Errorf("first request = %s, want %s", requested, tt.request)
+			}
+		})
+	}
+}
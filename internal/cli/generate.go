@@ -16,10 +16,14 @@
 package cli
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"resumectl/internal/generator"
+	"resumectl/internal/privacy"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
@@ -28,17 +32,29 @@ import (
 var (
 	htmlOnly bool
 	pdfOnly  bool
+	formats  []string
+	maxPages int
+
+	redactFields   string
+	obfuscateEmail bool
 )
 
 var generateCmd = &cobra.Command{
 	Use:   "generate",
-	Short: "Generate CV in HTML and/or PDF",
+	Short: "Generate CV in HTML, PDF and/or vCard",
 	Long: `Generate the CV from the specified YAML file.
 
 By default, generates both formats (HTML and PDF).
-Use --html or --pdf to generate a single format.
+Use --html or --pdf to generate a single format, or --format to list the
+formats to generate: html, pdf and vcf (a vCard 4.0 contact card).
 Use --theme to choose a theme (modern, classic, minimal, elegant, tech).
 Use --color to customize the primary color of any theme.
+Use --redact for a public version: the listed personal fields, the fields
+in personal.private and the entries marked "private: true" are left out.
+Use --max-pages to make the PDF fit: the spacing is tightened, then the
+least important content is dropped (interests, highlights beyond ` + strconv.Itoa(generator.FitHighlights) + `, projects,
+certifications, oldest experiences...) until it does. What was dropped is
+reported, and the HTML output matches the PDF.
 
 Usage examples:
   resumectl generate                              # Generate HTML and PDF (modern theme)
@@ -47,7 +63,13 @@ Usage examples:
   resumectl generate --theme tech --color #8b5cf6 # Tech theme with purple
   resumectl generate --html                       # Generate HTML only
   resumectl generate --pdf                        # Generate PDF only
-  resumectl generate -d my_cv.yaml                # Use a custom YAML file`,
+  resumectl generate --format vcf                 # Generate the vCard only
+  resumectl generate --format html,pdf,vcf        # Generate all formats
+  resumectl generate -d my_cv.yaml                # Use a custom YAML file
+  resumectl generate --html --redact phone,location --obfuscate-email
+                                                  # Public HTML version
+  resumectl generate --html --redact private      # Only drop what is marked private
+  resumectl generate --max-pages 1                # One-page PDF`,
 	Run: runGenerate,
 }
 
@@ -55,6 +77,16 @@ func init() {
 	rootCmd.AddCommand(generateCmd)
 	generateCmd.Flags().BoolVar(&htmlOnly, "html", false, "Generate HTML file only")
 	generateCmd.Flags().BoolVar(&pdfOnly, "pdf", false, "Generate PDF file only")
+	generateCmd.Flags().StringSliceVar(&formats, "format", nil, "Formats to generate: html, pdf, vcf (default html,pdf)")
+	generateCmd.Flags().IntVar(&maxPages, "max-pages", 0, "Shorten the CV until the PDF fits in this number of pages")
+	addPrivacyFlags(generateCmd)
+}
+
+// addPrivacyFlags registers the flags producing a public version of the CV
+func addPrivacyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&redactFields, "redact", "",
+		"Comma-separated personal fields to leave out ("+strings.Join(privacy.Fields, ", ")+"), along with everything marked private")
+	cmd.Flags().BoolVar(&obfuscateEmail, "obfuscate-email", false, "Assemble the email address with JavaScript to keep it from scrapers")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) {
@@ -66,6 +98,14 @@ func runGenerate(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatal("Error", "error", err)
 	}
+	gen.SetStructuredData(!noStructuredData)
+	gen.SetObfuscateEmail(obfuscateEmail)
+
+	redacted, err := privacy.ParseFields(redactFields)
+	if err != nil {
+		log.Fatal("Invalid --redact", "error", err)
+	}
+	gen.Redact(redacted)
 
 	cv := gen.GetCV()
 	if primaryColor != "" {
@@ -73,17 +113,28 @@ func runGenerate(cmd *cobra.Command, args []string) {
 	} else {
 		log.Info("Generating CV", "name", cv.Personal.FullName(), "theme", theme)
 	}
+	if len(redacted) > 0 {
+		log.Info("Redacting", "fields", strings.Join(redacted, ","))
+	}
 
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		log.Fatal("Error creating output directory", "error", err)
 	}
 
+	selected, err := outputFormats()
+	if err != nil {
+		log.Fatal("Invalid --format", "error", err)
+	}
+
+	if maxPages < 0 || maxPages > 0 && !selected["pdf"] {
+		log.Fatal("--max-pages needs a positive number of pages and the PDF format")
+	}
+
 	htmlPath := filepath.Join(outputDir, "cv.html")
 	pdfPath := filepath.Join(outputDir, "cv.pdf")
+	vcfPath := filepath.Join(outputDir, "cv.vcf")
 
-	generateBoth := !htmlOnly && !pdfOnly
-
-	if generateBoth || htmlOnly {
+	if selected["html"] {
 		log.Info("Generating HTML...")
 		if err := gen.GenerateHTML(htmlPath); err != nil {
 			log.Fatal("Error generating HTML", "error", err)
@@ -91,23 +142,86 @@ func runGenerate(cmd *cobra.Command, args []string) {
 		log.Info("HTML generated", "path", htmlPath)
 	}
 
-	if generateBoth || pdfOnly {
-		if pdfOnly {
+	if selected["pdf"] {
+		if !selected["html"] {
 			if err := gen.GenerateHTML(htmlPath); err != nil {
 				log.Fatal("Error generating intermediate HTML", "error", err)
 			}
 		}
 
 		log.Info("Generating PDF...")
-		if err := gen.GeneratePDF(htmlPath, pdfPath); err != nil {
-			log.Error("Error generating PDF", "error", err)
-			log.Info("Tip: Install Google Chrome or WeasyPrint")
-			log.Info("  - macOS/Linux: Chrome is often already installed")
-			log.Info("  - pip install weasyprint")
-			os.Exit(1)
+		if maxPages > 0 {
+			generateFittedPDF(gen, htmlPath, pdfPath)
+		} else {
+			if err := gen.GeneratePDF(htmlPath, pdfPath); err != nil {
+				pdfError(err)
+			}
+			if pages, err := generator.CountPages(pdfPath); err == nil {
+				log.Info("PDF generated", "path", pdfPath, "pages", pages)
+			} else {
+				log.Info("PDF generated", "path", pdfPath)
+			}
 		}
-		log.Info("PDF generated", "path", pdfPath)
+	}
+
+	if selected["vcf"] {
+		log.Info("Generating vCard...")
+		if err := gen.GenerateVCard(vcfPath); err != nil {
+			log.Fatal("Error generating vCard", "error", err)
+		}
+		log.Info("vCard generated", "path", vcfPath)
 	}
 
 	log.Info("Generation completed successfully")
 }
+
+// generateFittedPDF generates the PDF, shortening the CV until it has at
+// most --max-pages pages
+func generateFittedPDF(gen *generator.Generator, htmlPath, pdfPath string) {
+	pages, changes, err := gen.FitPages(htmlPath, pdfPath, maxPages)
+	if err != nil {
+		pdfError(err)
+	}
+	for _, change := range changes {
+		log.Info("Shortened to fit", "change", change)
+	}
+	if pages > maxPages {
+		log.Warn("The CV is still too long, shorten it by hand", "pages", pages, "max", maxPages)
+	}
+	log.Info("PDF generated", "path", pdfPath, "pages", pages)
+}
+
+// pdfError reports a PDF generation failure and exits
+func pdfError(err error) {
+	log.Error("Error generating PDF", "error", err)
+	log.Info("Tip: Install Google Chrome or WeasyPrint")
+	log.Info("  - macOS/Linux: Chrome is often already installed")
+	log.Info("  - pip install weasyprint")
+	os.Exit(1)
+}
+
+// outputFormats returns the formats selected by --format, --html and --pdf
+// (HTML and PDF when none is given)
+func outputFormats() (map[string]bool, error) {
+	selected := make(map[string]bool)
+	for _, format := range formats {
+		format = strings.ToLower(strings.TrimSpace(format))
+		switch format {
+		case "html", "pdf", "vcf":
+			selected[format] = true
+		default:
+			return nil, fmt.Errorf("unknown format %q (use html, pdf or vcf)", format)
+		}
+	}
+	if htmlOnly {
+		selected["html"] = true
+	}
+	if pdfOnly {
+		selected["pdf"] = true
+	}
+	if len(selected) == 0 {
+		selected["html"] = true
+		selected["pdf"] = true
+	}
+	return selected, nil
+}
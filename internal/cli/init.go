@@ -16,12 +16,15 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"resumectl/internal/github"
+	"resumectl/internal/gitlab"
 	"resumectl/internal/linkedin"
 	"resumectl/internal/models"
 
@@ -31,12 +34,19 @@ import (
 )
 
 var (
-	linkedinURL    string
-	outputFile     string
-	forceOverwrite bool
-	linkedinCookie string
-	githubUsername string
-	githubProjects int
+	linkedinURL     string
+	outputFile      string
+	forceOverwrite  bool
+	initInteractive bool
+	linkedinCookie  string
+	githubUsername  string
+	githubProjects  int
+	githubToken     string
+	githubAPIURL    string
+	githubRank      string
+	githubInclude   []string
+	githubExclude   []string
+	githubOrgs      bool
 )
 
 var initCmd = &cobra.Command{
@@ -48,8 +58,12 @@ This command creates a new cv.yaml file that you can edit with your information.
 If a LinkedIn profile URL is provided, it will fetch public information
 from the profile to pre-populate the CV.
 
-If a GitHub username is provided, it will fetch your top projects by stars
-and add them to the projects section.
+If a GitHub username is provided, it will fetch your top projects (by stars,
+or with --rank by recent activity, commit count or pinned order) and add them
+to the projects section with their languages, stars and forks. GitLab
+(--gitlab), Gitea, Forgejo and Bitbucket (--forge) projects are imported the
+same way. Skills are derived from the languages and topics of the imported
+repositories (disable with --skills=false).
 
 To get ALL LinkedIn profile data (experiences, education, etc.), you need to provide your
 LinkedIn session cookie (li_at). To get it:
@@ -59,10 +73,18 @@ LinkedIn session cookie (li_at). To get it:
 
 Usage examples:
   resumectl init                                     # Create an empty template
+  resumectl init --interactive                       # Fill in the CV step by step
   resumectl init --linkedin https://linkedin.com/in/johndoe
-  resumectl init --linkedin johndoe --cookie "AQEDAx..."  # With auth for full data
+  resumectl auth login linkedin && resumectl init --linkedin johndoe  # With auth for full data
   resumectl init --github juhnny5                    # Add top GitHub projects
   resumectl init --github juhnny5 --projects 10     # Add top 10 projects
+  resumectl init --github juhnny5 --rank recent      # Most recently pushed projects
+  resumectl init --github juhnny5 --exclude "dotfiles" --include "topic:kubernetes"
+  GITHUB_TOKEN=ghp_... resumectl init --github juhnny5  # Authenticated (higher rate limit)
+  resumectl init --github jdoe --github-api-url https://git.corp.com/api/v3  # GitHub Enterprise
+  resumectl init --gitlab jdoe                      # Add top GitLab projects
+  resumectl init --forge forgejo:https://git.example.com/jdoe  # Self-hosted Forgejo
+  resumectl init --forge gitea:jdoe --forge bitbucket:myworkspace
   resumectl init -f my-cv.yaml                       # Custom output file
   resumectl init --force                             # Overwrite existing file`,
 	Run: runInit,
@@ -70,12 +92,32 @@ Usage examples:
 
 func init() {
 	rootCmd.AddCommand(initCmd)
-	initCmd.Flags().StringVarP(&linkedinURL, "linkedin", "l", "", "LinkedIn profile URL or username")
 	initCmd.Flags().StringVarP(&outputFile, "file", "f", "cv.yaml", "Output file name")
 	initCmd.Flags().BoolVar(&forceOverwrite, "force", false, "Overwrite existing file without confirmation")
-	initCmd.Flags().StringVarP(&linkedinCookie, "cookie", "c", "", "LinkedIn session cookie (li_at) for full data access")
-	initCmd.Flags().StringVarP(&githubUsername, "github", "g", "", "GitHub username to fetch top projects")
-	initCmd.Flags().IntVarP(&githubProjects, "projects", "p", 5, "Number of top GitHub projects to fetch (default: 5)")
+	initCmd.Flags().BoolVarP(&initInteractive, "interactive", "i", false, "Fill in the CV with an interactive terminal wizard")
+	addImportFlags(initCmd)
+}
+
+// addImportFlags registers the LinkedIn and project import flags shared by init and sync
+func addImportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&linkedinURL, "linkedin", "l", "", "LinkedIn profile URL or username")
+	cmd.Flags().StringVarP(&linkedinCookie, "cookie", "c", "", "LinkedIn session cookie (li_at) for full data access (default: $LINKEDIN_COOKIE or stored credentials)")
+	cmd.Flags().StringVarP(&githubUsername, "github", "g", "", "GitHub username to fetch top projects")
+	cmd.Flags().IntVarP(&githubProjects, "projects", "p", 5, "Number of top projects to fetch per source (default: 5)")
+	cmd.Flags().StringVar(&githubToken, "github-token", "", "GitHub personal access token (default: $GITHUB_TOKEN or stored credentials)")
+	cmd.Flags().StringVar(&githubAPIURL, "github-api-url", "", "GitHub API base URL, for GitHub Enterprise (e.g. https://git.example.com/api/v3)")
+	cmd.Flags().StringVar(&gitlabUsername, "gitlab", "", "GitLab username to fetch top projects")
+	cmd.Flags().StringVar(&gitlabURL, "gitlab-url", gitlab.DefaultBaseURL, "GitLab instance URL")
+	cmd.Flags().StringVar(&gitlabToken, "gitlab-token", "", "GitLab personal access token (default: $GITLAB_TOKEN or stored credentials)")
+	cmd.Flags().StringArrayVar(&forgeSpecs, "forge", nil, "Fetch projects from another forge: kind:user or kind:https://host/user ("+strings.Join(forgeKinds, ", ")+")")
+	cmd.Flags().StringVar(&githubRank, "rank", github.RankStars, "Project ranking ("+strings.Join(github.RankStrategies, ", ")+")")
+	cmd.Flags().StringSliceVar(&githubInclude, "include", nil, "Only keep repositories matching a name glob or topic:<name>")
+	cmd.Flags().StringSliceVar(&githubExclude, "exclude", nil, "Skip repositories matching a name glob or topic:<name>")
+	cmd.Flags().BoolVar(&githubOrgs, "include-orgs", false, "Also consider repositories of your organizations")
+	cmd.Flags().BoolVar(&deriveSkills, "skills", true, "Derive skills from the languages and topics of the imported repositories")
+	cmd.Flags().BoolVar(&offlineMode, "offline", false, "Only use cached LinkedIn and forge data, never the network")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Do not read or write the on-disk cache")
+	cmd.Flags().StringToStringVar(&cacheTTLFlag, "cache-ttl", nil, cacheTTLUsage)
 }
 
 func runInit(cmd *cobra.Command, args []string) {
@@ -84,86 +126,107 @@ func runInit(cmd *cobra.Command, args []string) {
 		log.Fatal("File already exists. Use --force to overwrite", "file", outputFile)
 	}
 
+	setupCache()
+
 	var cv *models.CV
+	// Only what was actually imported goes into the sync snapshot, never the
+	// placeholders of the template
+	snapshot := &models.CV{}
+	var fetched bool
 
 	if linkedinURL != "" {
-		log.Info("Fetching LinkedIn profile...")
-
-		// Extract the username
-		username, err := linkedin.ExtractUsernameFromURL(linkedinURL)
-		if err != nil {
-			log.Warn("Could not extract username, using as-is", "error", err)
-			username = linkedinURL
-		}
-
-		log.Info("Looking up profile", "username", username)
-
-		// Fetch LinkedIn profile (with or without authentication)
-		var profile *linkedin.LinkedInProfile
-		if linkedinCookie != "" {
-			log.Info("Using authenticated session for full data access...")
-			linkedin.DebugMode = DebugMode
-			profile, err = linkedin.FetchProfileWithAuth(username, linkedinCookie)
-		} else {
-			profile, err = linkedin.FetchProfile(username)
+		cookie := linkedInCookie()
+		var err error
+		cv, err = fetchLinkedInCV(linkedinURL, cookie)
+		if err == nil {
+			*snapshot = *cv
+			fetched = true
 		}
-
 		if err != nil {
 			log.Warn("Could not fetch LinkedIn profile, creating template instead", "error", err)
-			cv = createEmptyCV()
-		} else {
-			log.Info("Profile found", "name", profile.FirstName+" "+profile.LastName)
-			cv = profile.ToCV(linkedinURL)
-
+			cv = newCV()
+		} else if cookie == "" {
 			// Warn about LinkedIn limitations if no cookie
-			if linkedinCookie == "" {
-				log.Warn("LinkedIn limits public data access. Some information may be missing or incomplete.")
-				fmt.Println("")
-				fmt.Println("  ⚠️  Note: LinkedIn masks most profile data for non-authenticated visitors.")
-				fmt.Println("      To get ALL data, use: --cookie <your_li_at_cookie>")
-				fmt.Println("")
-				fmt.Println("      How to get your cookie:")
-				fmt.Println("      1. Log in to LinkedIn in your browser")
-				fmt.Println("      2. Open DevTools (F12) > Application > Cookies > linkedin.com")
-				fmt.Println("      3. Copy the value of 'li_at' cookie")
-				fmt.Println("")
-			} else {
-				log.Info("Full profile data retrieved successfully!")
-			}
+			log.Warn("LinkedIn limits public data access. Some information may be missing or incomplete.")
+			fmt.Println("")
+			fmt.Println("  ⚠️  Note: LinkedIn masks most profile data for non-authenticated visitors.")
+			fmt.Println("      To get ALL data, run: resumectl auth login linkedin")
+			fmt.Println("")
+			fmt.Println("      How to get your cookie:")
+			fmt.Println("      1. Log in to LinkedIn in your browser")
+			fmt.Println("      2. Open DevTools (F12) > Application > Cookies > linkedin.com")
+			fmt.Println("      3. Copy the value of 'li_at' cookie")
+			fmt.Println("")
+		} else {
+			log.Info("Full profile data retrieved successfully!")
 		}
 	} else {
-		cv = createEmptyCV()
+		cv = newCV()
 	}
 
 	// Fetch GitHub projects if a username is provided
+	var imported []github.Project
 	if githubUsername != "" {
-		log.Info("Fetching GitHub projects...")
-
-		username := github.ExtractUsernameFromURL(githubUsername)
-		log.Info("Looking up GitHub profile", "username", username)
-
-		github.DebugMode = DebugMode
-		projects, err := github.FetchTopProjects(username, githubProjects)
+		username, projects, err := fetchGitHubProjects(githubUsername, githubProjects)
 		if err != nil {
 			log.Warn("Could not fetch GitHub projects", "error", err)
 		} else {
-			log.Info("GitHub projects fetched successfully!", "count", len(projects))
-
-			// Convert and add projects to CV
-			for _, proj := range projects {
-				cvProject := models.Project{
-					Name:         proj.Name,
-					Description:  proj.Description,
-					URL:          proj.URL,
-					Technologies: proj.Technologies,
-				}
-				cv.Projects = append(cv.Projects, cvProject)
-			}
+			imported = append(imported, projects...)
 
 			// Update GitHub link in personal info
 			if cv.Personal.GitHub == "" || cv.Personal.GitHub == "github.com/yourusername" {
 				cv.Personal.GitHub = "github.com/" + username
 			}
+			snapshot.Personal.GitHub = "github.com/" + username
+			fetched = true
+		}
+	}
+
+	// Fetch GitLab, Gitea/Forgejo and Bitbucket projects
+	if gitlabUsername != "" || len(forgeSpecs) > 0 {
+		projects, err := fetchForgeImports()
+		if err != nil {
+			log.Warn("Could not fetch projects", "error", err)
+		} else {
+			imported = append(imported, projects...)
+		}
+	}
+
+	if len(imported) > 0 {
+		cv.Projects = append(cv.Projects, toCVProjects(imported)...)
+
+		// Replace the placeholder skills of the template by real ones
+		if reflect.DeepEqual(cv.Skills, createEmptyCV().Skills) {
+			cv.Skills = nil
+		}
+		importSkills(cv, imported)
+
+		snapshot.Projects = toCVProjects(imported)
+		snapshot.Skills = cv.Skills
+		fetched = true
+	}
+
+	// Snapshot the imported data before the wizard: its answers are hand
+	// edits that "resumectl sync" must keep
+	var base []byte
+	if fetched {
+		// LinkedIn never provides these: ToCV only fills in placeholders
+		snapshot.Personal.Email = ""
+		snapshot.Personal.Phone = ""
+
+		var err error
+		if base, err = syncBase(snapshot); err != nil {
+			log.Warn("Could not save import snapshot", "error", err)
+		}
+	}
+
+	if initInteractive {
+		if err := runWizard(cv); err != nil {
+			if errors.Is(err, errWizardQuit) {
+				log.Info("Cancelled, no file written")
+				return
+			}
+			log.Fatal("Error running wizard", "error", err)
 		}
 	}
 
@@ -172,6 +235,13 @@ func runInit(cmd *cobra.Command, args []string) {
 		log.Fatal("Error writing CV file", "error", err)
 	}
 
+	// Remember what was imported so that "resumectl sync" can tell hand edits apart
+	if base != nil {
+		if err := os.WriteFile(syncBasePath(outputFile), base, 0644); err != nil {
+			log.Warn("Could not save import snapshot", "error", err)
+		}
+	}
+
 	absPath, _ := filepath.Abs(outputFile)
 	log.Info("CV file created successfully!", "path", absPath)
 	log.Info("Next steps:")
@@ -180,6 +250,83 @@ func runInit(cmd *cobra.Command, args []string) {
 	fmt.Println("  3. Preview in browser: resumectl serve")
 }
 
+// linkedInCookie returns the li_at cookie from --cookie, $LINKEDIN_COOKIE or
+// the stored credentials
+func linkedInCookie() string {
+	if linkedinCookie != "" {
+		log.Warn("--cookie exposes your LinkedIn session in shell history, prefer: resumectl auth login linkedin")
+	}
+	return credential("linkedin", linkedinCookie)
+}
+
+// fetchLinkedInCV fetches a LinkedIn profile and converts it to a CV
+func fetchLinkedInCV(profileURL, cookie string) (*models.CV, error) {
+	log.Info("Fetching LinkedIn profile...")
+
+	// Extract the username
+	username, err := linkedin.ExtractUsernameFromURL(profileURL)
+	if err != nil {
+		log.Warn("Could not extract username, using as-is", "error", err)
+		username = profileURL
+	}
+
+	log.Info("Looking up profile", "username", username)
+
+	// Fetch LinkedIn profile (with or without authentication)
+	var profile *linkedin.LinkedInProfile
+	if cookie != "" {
+		log.Info("Using authenticated session for full data access...")
+		linkedin.DebugMode = DebugMode
+		profile, err = linkedin.FetchProfileWithAuth(username, cookie)
+	} else {
+		profile, err = linkedin.FetchProfile(username)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Profile found", "name", profile.FirstName+" "+profile.LastName)
+	return profile.ToCV(profileURL), nil
+}
+
+// fetchGitHubProjects fetches the top projects of a GitHub user. It also
+// returns the username extracted from input.
+func fetchGitHubProjects(input string, count int) (string, []github.Project, error) {
+	log.Info("Fetching GitHub projects...")
+
+	username := github.ExtractUsernameFromURL(input)
+	log.Info("Looking up GitHub profile", "username", username)
+
+	github.DebugMode = DebugMode
+	github.Token = credential("github", githubToken)
+	if githubAPIURL != "" {
+		github.APIBaseURL = githubAPIURL
+	}
+
+	projects, err := github.FetchProjects(username, github.Options{
+		Count:       count,
+		Rank:        githubRank,
+		Include:     githubInclude,
+		Exclude:     githubExclude,
+		IncludeOrgs: githubOrgs,
+	})
+	if err != nil {
+		return username, nil, err
+	}
+	log.Info("GitHub projects fetched successfully!", "count", len(projects))
+
+	return username, projects, nil
+}
+
+// newCV returns the CV to start from without LinkedIn data: a blank one for
+// the wizard, the placeholder template otherwise
+func newCV() *models.CV {
+	if initInteractive {
+		return &models.CV{Personal: models.Personal{PhotoShape: "round"}}
+	}
+	return createEmptyCV()
+}
+
 // createEmptyCV creates an empty CV template
 func createEmptyCV() *models.CV {
 	return &models.CV{
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"resumectl/internal/generator"
+	"resumectl/internal/privacy"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	letterHTMLOnly bool
+	letterCombined bool
+)
+
+var letterCmd = &cobra.Command{
+	Use:   "letter [letter.yaml]",
+	Short: "Generate a cover letter matching the CV",
+	Long: `Generate a cover letter in HTML and PDF with the theme and header of
+the CV. The letter is described in its own YAML file (letter.yaml by
+default): recipient, company, address, date, subject, greeting, body
+paragraphs and closing. The paragraphs can refer to the CV fields:
+
+  body:
+    - >
+      As a {{.Personal.Title}} at {{(index .Experience 0).Company}}, I was
+      excited to see the opening at {{.Letter.Company}}.
+
+The files are named after the letter file (acme.yaml -> acme.html, acme.pdf).
+Use --combined to also get a single PDF with the letter followed by the CV
+(a single HTML file with --html), and --redact as with generate to leave
+private data out of it.
+
+Usage examples:
+  resumectl letter                          # letter.yaml -> letter.html, letter.pdf
+  resumectl letter acme.yaml --theme elegant
+  resumectl letter acme.yaml --combined     # Also acme-cv.pdf (letter + CV)
+  resumectl letter acme.yaml --combined --redact private
+                                            # Without the entries marked private
+  resumectl letter acme.yaml --html         # HTML only
+  resumectl letter acme.yaml --html --combined
+                                            # acme.html and acme-cv.html`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runLetter,
+}
+
+func init() {
+	rootCmd.AddCommand(letterCmd)
+	letterCmd.Flags().BoolVar(&letterHTMLOnly, "html", false, "Generate HTML file only")
+	letterCmd.Flags().BoolVar(&letterCombined, "combined", false, "Also generate a PDF (HTML with --html) with the letter followed by the CV")
+	addPrivacyFlags(letterCmd)
+}
+
+func runLetter(cmd *cobra.Command, args []string) {
+	letterPath := "letter.yaml"
+	if len(args) > 0 {
+		letterPath = args[0]
+	}
+	if _, err := os.Stat(dataPath); os.IsNotExist(err) {
+		log.Fatal("Data file does not exist", "path", dataPath)
+	}
+	if _, err := os.Stat(letterPath); os.IsNotExist(err) {
+		log.Fatal("Letter file does not exist", "path", letterPath)
+	}
+
+	gen, err := generator.NewWithColor(dataPath, theme, primaryColor, outputDir)
+	if err != nil {
+		log.Fatal("Error", "error", err)
+	}
+	gen.SetStructuredData(!noStructuredData)
+	gen.SetObfuscateEmail(obfuscateEmail)
+
+	redacted, err := privacy.ParseFields(redactFields)
+	if err != nil {
+		log.Fatal("Invalid --redact", "error", err)
+	}
+	gen.Redact(redacted)
+	if len(redacted) > 0 {
+		log.Info("Redacting", "fields", strings.Join(redacted, ","))
+	}
+
+	letter, err := generator.LoadLetter(letterPath)
+	if err != nil {
+		log.Fatal("Error", "error", err)
+	}
+
+	log.Info("Generating cover letter", "name", gen.GetCV().Personal.FullName(), "company", letter.Company, "theme", theme)
+
+	name := strings.TrimSuffix(filepath.Base(letterPath), filepath.Ext(letterPath))
+	htmlPath := filepath.Join(outputDir, name+".html")
+	pdfPath := filepath.Join(outputDir, name+".pdf")
+
+	if err := gen.GenerateLetter(letter, htmlPath, false); err != nil {
+		log.Fatal("Error generating HTML", "error", err)
+	}
+	log.Info("HTML generated", "path", htmlPath)
+
+	if !letterHTMLOnly {
+		if err := gen.GeneratePDF(htmlPath, pdfPath); err != nil {
+			log.Fatal("Error generating PDF", "error", err)
+		}
+		log.Info("PDF generated", "path", pdfPath)
+	}
+
+	if letterCombined {
+		combinedHTML := filepath.Join(outputDir, name+"-cv.html")
+		combinedPDF := filepath.Join(outputDir, name+"-cv.pdf")
+		if err := gen.GenerateLetter(letter, combinedHTML, true); err != nil {
+			log.Fatal("Error generating combined HTML", "error", err)
+		}
+		if letterHTMLOnly {
+			log.Info("Combined HTML generated", "path", combinedHTML)
+			return
+		}
+		err := gen.GeneratePDF(combinedHTML, combinedPDF)
+		os.Remove(combinedHTML)
+		if err != nil {
+			log.Fatal("Error generating combined PDF", "error", err)
+		}
+		log.Info("Combined PDF generated", "path", combinedPDF)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"resumectl/internal/generator"
+	"resumectl/internal/lint"
+	"resumectl/internal/spell"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+// Files looked up next to the CV file
+const (
+	lintConfigFile = ".resumectl-lint.yaml"
+	lintWordsFile  = ".resumectl-words.txt"
+)
+
+var (
+	lintConfigPath string
+	lintListRules  bool
+	lintSpell      bool
+	lintLangs      []string
+	lintWordsPath  string
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check the content quality of the CV",
+	Long: `Check the content of the CV beyond schema validity: weak verbs and
+length of highlights, results without figures, inconsistent date formats,
+overlapping or gapped employment periods, duplicated skills, summary length
+and placeholders left from 'resumectl init'.
+
+Rules can be disabled or given another severity (off, info, warning, error)
+in a .resumectl-lint.yaml file next to the CV, or the file given with --config:
+
+  rules:
+    weak-verb:
+      severity: off
+    highlight-length:
+      severity: error
+      max: 120
+
+With --spell, the prose (summary, titles, descriptions, highlights) is also
+checked for typos and repeated words against the built-in Hunspell
+dictionaries (en, fr, de). Names from the CV (people, companies, skills,
+projects) are always accepted; add other words, one per line, to a
+.resumectl-words.txt file next to the CV or to the file given with --words.
+Other languages are looked up in $DICPATH, ~/.config/resumectl/dictionaries
+and the usual Hunspell locations.
+
+The command exits with status 1 when an error is found.
+
+Usage examples:
+  resumectl lint                    # Check cv.yaml
+  resumectl lint -d my_cv.yaml      # Check a custom YAML file
+  resumectl lint --spell            # Also check the spelling, in English
+  resumectl lint --spell --lang fr,en  # French and English words
+  resumectl lint --list-rules       # Show the rules and their defaults`,
+	Run: runLint,
+}
+
+func init() {
+	lintCmd.Flags().StringVar(&lintConfigPath, "config", "", "Lint configuration file (default: "+lintConfigFile+" next to the CV)")
+	lintCmd.Flags().BoolVar(&lintListRules, "list-rules", false, "List the rules with their default severity")
+	lintCmd.Flags().BoolVar(&lintSpell, "spell", false, "Check the spelling of the prose")
+	lintCmd.Flags().StringSliceVar(&lintLangs, "lang", []string{"en"}, "Dictionary languages for --spell (en, fr, de)")
+	lintCmd.Flags().StringVar(&lintWordsPath, "words", "", "Personal word list for --spell (default: "+lintWordsFile+" next to the CV)")
+	rootCmd.AddCommand(lintCmd)
+}
+
+func runLint(cmd *cobra.Command, args []string) {
+	if lintListRules {
+		for _, rule := range lint.Rules {
+			line := fmt.Sprintf("%-20s %-8s %s", rule.Name, rule.Severity, rule.Description)
+			if rule.Max > 0 {
+				line += fmt.Sprintf(" (max: %d)", rule.Max)
+			}
+			fmt.Println(line)
+		}
+		return
+	}
+
+	config, err := loadLintConfig()
+	if err != nil {
+		log.Fatal("Error reading lint configuration", "error", err)
+	}
+
+	gen, err := generator.New(dataPath, theme, "")
+	if err != nil {
+		log.Fatal("Validation error", "error", err)
+	}
+
+	var speller *spell.Checker
+	if lintSpell {
+		if speller, err = loadSpeller(); err != nil {
+			log.Fatal("Error loading dictionaries", "error", err)
+		}
+	}
+
+	findings := lint.Run(gen.GetCV(), config, speller)
+	if data, err := os.ReadFile(dataPath); err == nil {
+		lint.Locate(findings, data)
+	}
+
+	counts := make(map[lint.Severity]int)
+	for _, f := range findings {
+		location := dataPath
+		if f.Line > 0 {
+			location = fmt.Sprintf("%s:%d", dataPath, f.Line)
+		}
+		fmt.Printf("%s: %s: %s: %s (%s)\n", location, f.Severity, f.Path, f.Message, f.Rule)
+		counts[f.Severity]++
+	}
+
+	if len(findings) == 0 {
+		log.Info("No problem found")
+		return
+	}
+	fmt.Println()
+	log.Info("Lint finished", "errors", counts[lint.Error], "warnings", counts[lint.Warning], "info", counts[lint.Info])
+	if counts[lint.Error] > 0 {
+		os.Exit(1)
+	}
+}
+
+// loadLintConfig reads the --config file, or the default one next to the
+// CV if it exists. It returns nil to use the default rules.
+func loadLintConfig() (*lint.Config, error) {
+	if lintConfigPath != "" {
+		return lint.LoadConfig(lintConfigPath)
+	}
+	path := filepath.Join(filepath.Dir(dataPath), lintConfigFile)
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil
+	}
+	log.Debug("Using lint configuration", "path", path)
+	return lint.LoadConfig(path)
+}
+
+// loadSpeller loads the --lang dictionaries and the personal word list
+func loadSpeller() (*spell.Checker, error) {
+	speller, err := spell.NewChecker(lintLangs)
+	if err != nil {
+		return nil, err
+	}
+
+	path := lintWordsPath
+	if path == "" {
+		path = filepath.Join(filepath.Dir(dataPath), lintWordsFile)
+		if _, err := os.Stat(path); err != nil {
+			return speller, nil
+		}
+	}
+	if err := speller.LoadWordList(path); err != nil {
+		return nil, fmt.Errorf("word list: %w", err)
+	}
+	return speller, nil
+}
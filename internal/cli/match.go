@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"resumectl/internal/generator"
+	"resumectl/internal/match"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var matchCmd = &cobra.Command{
+	Use:   "match <job.txt>",
+	Short: "Compare the CV with a job posting",
+	Long: `Extract the skill and technology keywords of a job posting and look for
+them in the CV: skills, experience, projects, certifications and summary.
+
+Prints a coverage score (the share of the posting keywords found in the CV,
+weighted by how often the posting mentions them), the keywords well covered
+(found in several sections), those found only once and those missing.
+
+Use - to read the posting from the standard input.
+
+Usage examples:
+  resumectl match job.txt                 # Compare cv.yaml with job.txt
+  resumectl match job.txt -d my_cv.yaml   # Use a custom YAML file
+  pbpaste | resumectl match -             # Posting from the clipboard`,
+	Args: cobra.ExactArgs(1),
+	Run:  runMatch,
+}
+
+func init() {
+	rootCmd.AddCommand(matchCmd)
+}
+
+func runMatch(cmd *cobra.Command, args []string) {
+	var posting []byte
+	var err error
+	if args[0] == "-" {
+		posting, err = io.ReadAll(os.Stdin)
+	} else {
+		posting, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		log.Fatal("Error reading job posting", "error", err)
+	}
+
+	gen, err := generator.New(dataPath, theme, "")
+	if err != nil {
+		log.Fatal("Error", "error", err)
+	}
+
+	report := match.Compare(gen.GetCV(), string(posting))
+	if report.Total() == 0 {
+		log.Warn("No skill or technology keyword found in the job posting")
+		return
+	}
+
+	fmt.Printf("Match score: %d%% (%d of %d keywords found in the CV)\n",
+		report.Score, len(report.WellCovered)+len(report.Covered), report.Total())
+	printKeywords("Well covered", report.WellCovered)
+	printKeywords("Found once", report.Covered)
+	printKeywords("Missing", report.Missing)
+}
+
+// printKeywords prints a group of keywords with their mentions in the
+// posting and the CV sections they were found in
+func printKeywords(title string, keywords []match.Keyword) {
+	if len(keywords) == 0 {
+		return
+	}
+
+	width := 0
+	for _, k := range keywords {
+		width = max(width, len(k.Name))
+	}
+
+	fmt.Println()
+	fmt.Printf("%s (%d):\n", title, len(keywords))
+	for _, k := range keywords {
+		mentions := ""
+		if k.Occurrences > 1 {
+			mentions = fmt.Sprintf("%d×", k.Occurrences)
+		}
+		line := fmt.Sprintf("  %-*s  %3s  %s", width, k.Name, mentions, strings.Join(k.Sources, ", "))
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+}
@@ -19,8 +19,11 @@ import (
 	"fmt"
 	"os"
 
+	"resumectl/internal/generator"
 	"resumectl/internal/templates"
+	"resumectl/internal/vcard"
 
+	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 )
 
@@ -31,6 +34,8 @@ var (
 	theme        string
 	primaryColor string
 	DebugMode    bool
+
+	noStructuredData bool
 )
 
 var rootCmd = &cobra.Command{
@@ -69,4 +74,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&theme, "theme", "modern", "CV theme ("+templates.GetThemeNames()+")")
 	rootCmd.PersistentFlags().StringVar(&primaryColor, "color", "", "Custom primary color for any theme (hex, e.g. #ff5733)")
 	rootCmd.PersistentFlags().BoolVar(&DebugMode, "debug", false, "Enable debug output")
+	rootCmd.PersistentFlags().BoolVar(&noStructuredData, "no-structured-data", false, "Leave JSON-LD and microformats out of the HTML (for privacy)")
+
+	generator.Warn = func(msg string, keyvals ...any) { log.Warn(msg, keyvals...) }
+	vcard.Warn = generator.Warn
 }
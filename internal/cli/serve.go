@@ -17,11 +17,15 @@ package cli
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -30,11 +34,27 @@ import (
 	"resumectl/internal/generator"
 
 	"github.com/charmbracelet/log"
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
 )
 
 var (
-	port int
+	port       int
+	serveHost  string
+	editorMode bool
+	serveTLS   bool
+	tlsCert    string
+	tlsKey     string
+	basicAuth  string
+	serveToken string
+)
+
+// Timeouts of the preview server. Event streams lift the write timeout.
+const (
+	readHeaderTimeout = 10 * time.Second
+	readTimeout       = 30 * time.Second
+	writeTimeout      = 2 * time.Minute // PDF rendering can be slow
+	idleTimeout       = 2 * time.Minute
 )
 
 var serveCmd = &cobra.Command{
@@ -42,101 +62,495 @@ var serveCmd = &cobra.Command{
 	Short: "Start a live preview server",
 	Long: `Start a local web server to preview your CV with live reload.
 
-The server watches for changes in your YAML file and automatically
-regenerates the HTML, refreshing the browser automatically.
+The server watches your YAML file and the files it refers to (such as
+the photo) for changes, regenerates the HTML and pushes the update to
+open browsers. Theme-only changes are applied
+without reloading the page.
+
+When the YAML file cannot be parsed, the page shows the error with the
+offending lines until the file is fixed.
+
+With --editor, the server also prints a private link to a browser editor:
+the YAML file on the left, with completion from the CV schema, and the
+preview on the right. Saves are refused if the file was changed on disk
+in the meantime.
+
+A toolbar on the page switches the theme and primary color on the fly
+and downloads the CV as PDF with the current settings.
+
+The server only listens on 127.0.0.1 by default. To share a preview on
+the network, use --host 0.0.0.0 with --basic-auth or --token, and --tls
+to encrypt the traffic with a self-signed certificate (kept in the user
+configuration directory) or your own with --tls-cert and --tls-key.
 
 Usage examples:
   resumectl serve                    # Start server on port 8080
   resumectl serve --port 3000        # Use a custom port
   resumectl serve -d my_cv.yaml      # Use a custom YAML file
-  resumectl serve --theme elegant    # Use a specific theme`,
+  resumectl serve --theme elegant    # Use a specific theme
+  resumectl serve --editor           # Edit the YAML file in the browser
+  resumectl serve --host 0.0.0.0 --tls --basic-auth me:secret  # Share on the LAN`,
 	Run: runServe,
 }
 
 func init() {
 	rootCmd.AddCommand(serveCmd)
 	serveCmd.Flags().IntVarP(&port, "port", "p", 8080, "Server port")
+	serveCmd.Flags().StringVar(&serveHost, "host", "127.0.0.1", "Address to listen on (0.0.0.0 to share on the network)")
+	serveCmd.Flags().BoolVar(&editorMode, "editor", false, "Also serve a browser editor saving to the YAML file")
+	serveCmd.Flags().BoolVar(&serveTLS, "tls", false, "Serve over HTTPS with a self-signed certificate")
+	serveCmd.Flags().StringVar(&tlsCert, "tls-cert", "", "TLS certificate file (implies --tls)")
+	serveCmd.Flags().StringVar(&tlsKey, "tls-key", "", "TLS private key file (implies --tls)")
+	serveCmd.Flags().StringVar(&basicAuth, "basic-auth", "", "Require HTTP basic auth with these credentials (user:password)")
+	serveCmd.Flags().StringVar(&serveToken, "token", "", "Require this token, passed once as ?token= in the URL")
 }
 
-// liveReloadScript injects JavaScript for auto-refresh
+// liveReloadScript injects JavaScript listening for server events: "reload"
+// refreshes the page, "css" swaps the theme stylesheet in place, "error"
+// and "clear" show and hide the error overlay
 const liveReloadScript = `<script>
 (function() {
-    let lastModified = '';
-    setInterval(function() {
-        fetch('/_reload')
+    const events = new EventSource('/_events');
+    events.addEventListener('error', function(e) {
+        // EventSource also fires "error" on connection problems
+        if (e.data) {
+            fetch('/_api/error').then(r => r.json()).then(showErrorOverlay);
+        }
+    });
+    events.addEventListener('clear', function() {
+        const overlay = document.getElementById('resumectl-error');
+        if (overlay) {
+            overlay.remove();
+        }
+    });
+    events.addEventListener('reload', function() {
+        location.reload();
+    });
+    events.addEventListener('css', function() {
+        fetch('/_theme.css')
             .then(r => r.text())
-            .then(t => {
-                if (lastModified && lastModified !== t) {
+            .then(css => {
+                const style = document.querySelector('head style');
+                if (style) {
+                    style.textContent = css;
+                } else {
                     location.reload();
                 }
-                lastModified = t;
             })
-            .catch(() => {});
-    }, 500);
+            .catch(() => location.reload());
+    });
 })();
 </script>`
 
+// debounceDelay groups the burst of events editors produce on save
+const debounceDelay = 100 * time.Millisecond
+
+// keepAliveInterval is how often an idle event stream gets a comment so
+// that proxies do not close it
+const keepAliveInterval = 30 * time.Second
+
 type liveServer struct {
-	dataPath    string
-	outputDir   string
-	theme       string
-	color       string
-	lastModTime string
-	mu          sync.RWMutex
+	dataPath  string
+	outputDir string
+
+	genMu  sync.Mutex // Serializes regenerations writing cv.html
+	saveMu sync.Mutex // Serializes saves from the editor
+
+	editorToken string // Protects the editor API; empty when the editor is disabled
+
+	mu       sync.RWMutex
+	theme    string
+	color    string
+	css      string      // Theme CSS of the last generated page
+	page     string      // Last generated page without its theme CSS
+	buildErr *buildError // Error of the last generation, shown in the browser
+	watcher  *fsnotify.Watcher
+	deps     map[string]bool // Absolute paths of the files read by the last generation
+	dirs     map[string]bool // Directories added to the watcher
+	clients  map[chan string]struct{}
+	done     <-chan struct{} // Closed on shutdown to end event streams
 }
 
-func (s *liveServer) regenerate() error {
-	gen, err := generator.NewWithColor(s.dataPath, s.theme, s.color, s.outputDir)
+// regenerate renders the CV and returns the event to send to browsers:
+// "css" when only the theme stylesheet changed, "reload" otherwise, "clear"
+// when a previous error is fixed without changing the output, or an empty
+// string when the output did not change at all. On failure the error is
+// kept for the browser overlay and the event is "error".
+func (s *liveServer) regenerate() (string, error) {
+	s.genMu.Lock()
+	defer s.genMu.Unlock()
+
+	s.mu.RLock()
+	theme, color := s.theme, s.color
+	s.mu.RUnlock()
+
+	css, page, deps, err := s.render(theme, color)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// A file that fails to parse gives no dependencies: keep the previous ones
+	if deps != nil {
+		s.setDependencies(deps)
+	}
+
 	if err != nil {
-		return err
+		s.buildErr = newBuildError(err, s.dataPath)
+		return "error", err
+	}
+	hadError := s.buildErr != nil
+	s.buildErr = nil
+
+	event := "reload"
+	switch {
+	case page == s.page && css == s.css:
+		event = ""
+	case page == s.page:
+		event = "css"
+	}
+	if event == "" && hadError {
+		event = "clear"
 	}
+	s.css, s.page = css, page
+
+	return event, nil
+}
+
+// render generates cv.html and returns its theme CSS, the rest of the page
+// and the files it was rendered from
+func (s *liveServer) render(theme, color string) (css, page string, deps []string, err error) {
+	gen, err := generator.NewWithColor(s.dataPath, theme, color, s.outputDir)
+	if err != nil {
+		return "", "", nil, err
+	}
+	gen.SetStructuredData(!noStructuredData)
 
 	htmlPath := filepath.Join(s.outputDir, "cv.html")
 	if err := gen.GenerateHTML(htmlPath); err != nil {
+		return "", "", gen.Dependencies(), err
+	}
+
+	content, err := os.ReadFile(htmlPath)
+	if err != nil {
+		return "", "", gen.Dependencies(), err
+	}
+	css, page = splitThemeCSS(string(content))
+	return css, page, gen.Dependencies(), nil
+}
+
+// splitThemeCSS returns the content of the first <style> element of a page
+// and the page with that content removed
+func splitThemeCSS(html string) (css, page string) {
+	start := strings.Index(html, "<style>")
+	if start == -1 {
+		return "", html
+	}
+	start += len("<style>")
+	end := strings.Index(html[start:], "</style>")
+	if end == -1 {
+		return "", html
+	}
+	end += start
+	return html[start:end], html[:start] + html[end:]
+}
+
+// watch regenerates the CV when one of its input files changes. Their
+// directories are watched rather than the files themselves so that editors
+// saving through a rename keep being followed, and so that files which do
+// not exist yet are picked up when they are created.
+func (s *liveServer) watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
 		return err
 	}
 
 	s.mu.Lock()
-	s.lastModTime = time.Now().Format(time.RFC3339Nano)
+	s.watcher = watcher
+	s.track()
 	s.mu.Unlock()
 
-	return nil
-}
+	go func() {
+		defer watcher.Close()
 
-func (s *liveServer) watchFile(ctx context.Context) {
-	var lastModTime time.Time
+		debounce := time.NewTimer(debounceDelay)
+		debounce.Stop()
+		var changed string
 
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				debounce.Stop()
+				return
 
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			info, err := os.Stat(s.dataPath)
-			if err != nil {
-				continue
-			}
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) || !s.isDependency(event.Name) {
+					continue
+				}
+				changed = event.Name
+				if event.Has(fsnotify.Create) {
+					// A directory leading to an input file may have appeared
+					s.mu.Lock()
+					s.track()
+					s.mu.Unlock()
+				}
+				debounce.Reset(debounceDelay)
 
-			if info.ModTime().After(lastModTime) {
-				lastModTime = info.ModTime()
-				log.Info("File changed, regenerating...", "file", s.dataPath)
-				if err := s.regenerate(); err != nil {
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Warn("File watcher error", "error", err)
+
+			case <-debounce.C:
+				if _, err := os.Stat(s.dataPath); err != nil {
+					// Removed or mid-rename: wait for it to come back
+					continue
+				}
+				log.Info("File changed, regenerating...", "file", changed)
+				event, err := s.regenerate()
+				if err != nil {
 					log.Error("Error regenerating", "error", err)
 				} else {
 					log.Info("CV regenerated successfully")
 				}
+				if event == "" && !sameAbsPath(changed, s.dataPath) {
+					// The page is unchanged but a file it links to is not
+					event = "reload"
+				}
+				if event != "" {
+					s.broadcast(event)
+				}
 			}
 		}
+	}()
+
+	return nil
+}
+
+// setDependencies replaces the set of watched input files. The data file is
+// always part of it. Callers hold s.mu.
+func (s *liveServer) setDependencies(files []string) {
+	s.deps = make(map[string]bool)
+	for _, file := range append([]string{s.dataPath}, files...) {
+		if abs, err := filepath.Abs(file); err == nil {
+			s.deps[abs] = true
+		}
+	}
+	s.track()
+}
+
+// track makes the watcher follow the directories of the dependencies, and
+// only those. For a directory that does not exist yet, its closest existing
+// parent is watched until it is created. Callers hold s.mu.
+func (s *liveServer) track() {
+	if s.watcher == nil {
+		return
+	}
+
+	wanted := make(map[string]bool)
+	for file := range s.deps {
+		dir := filepath.Dir(file)
+		for {
+			if info, err := os.Stat(dir); err == nil && info.IsDir() {
+				break
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+		wanted[dir] = true
+	}
+
+	for dir := range wanted {
+		if s.dirs[dir] {
+			continue
+		}
+		if err := s.watcher.Add(dir); err != nil {
+			log.Debug("Cannot watch directory", "dir", dir, "error", err)
+			continue
+		}
+		s.dirs[dir] = true
+	}
+	for dir := range s.dirs {
+		if !wanted[dir] {
+			s.watcher.Remove(dir)
+			delete(s.dirs, dir)
+		}
+	}
+}
+
+// sameAbsPath reports whether two paths resolve to the same absolute path
+func sameAbsPath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// isDependency reports whether path is one of the input files, or a
+// directory leading to one that did not exist yet
+func (s *liveServer) isDependency(path string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.deps[abs] {
+		return true
+	}
+	for file := range s.deps {
+		if strings.HasPrefix(file, abs+string(filepath.Separator)) {
+			return true
+		}
 	}
+	return false
+}
+
+// subscribe registers a browser connection for server events
+func (s *liveServer) subscribe() chan string {
+	ch := make(chan string, 1)
+	s.mu.Lock()
+	s.clients[ch] = struct{}{}
+	s.mu.Unlock()
+	return ch
 }
 
-func (s *liveServer) handleReload(w http.ResponseWriter, r *http.Request) {
+func (s *liveServer) unsubscribe(ch chan string) {
+	s.mu.Lock()
+	delete(s.clients, ch)
+	s.mu.Unlock()
+}
+
+// broadcast sends an event to all connected browsers. The event replaces
+// one still pending for a slow client, except that a pending "reload" is
+// kept over a "css" or "clear", which it already covers.
+func (s *liveServer) broadcast(event string) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	w.Header().Set("Content-Type", "text/plain")
-	fmt.Fprint(w, s.lastModTime)
+	for ch := range s.clients {
+		select {
+		case ch <- event:
+			continue
+		default:
+		}
+
+		next := event
+		select {
+		case pending := <-ch:
+			if pending == "reload" && (event == "css" || event == "clear") {
+				next = pending
+			}
+		default:
+		}
+		select {
+		case ch <- next:
+		default:
+		}
+	}
+}
+
+// handleEvents streams server events to a browser (Server-Sent Events)
+func (s *liveServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	// The stream outlives the server write timeout
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	ch := s.subscribe()
+	defer s.unsubscribe(ch)
+
+	// Reconnect quickly after the server restarts
+	fmt.Fprint(w, "retry: 1000\n\n")
+
+	// Pages loaded while the CV is broken show the error right away
+	s.mu.RLock()
+	if s.buildErr != nil {
+		fmt.Fprint(w, "event: error\ndata: error\n\n")
+	}
+	s.mu.RUnlock()
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		case event := <-ch:
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, event)
+			flusher.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// handleThemeCSS serves the theme stylesheet of the last generated page
+func (s *liveServer) handleThemeCSS(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	css := s.css
+	s.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "text/css; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprint(w, css)
+}
+
+// staticFile maps a URL path to a regular file of the output directory. It
+// refuses hidden files and anything resolving outside of the directory,
+// including through symbolic links.
+func (s *liveServer) staticFile(urlPath string) (string, bool) {
+	clean := path.Clean("/" + urlPath)
+	for _, segment := range strings.Split(clean, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return "", false
+		}
+	}
+
+	root, err := filepath.EvalSymlinks(s.outputDir)
+	if err != nil {
+		return "", false
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(root, filepath.FromSlash(clean)))
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	info, err := os.Stat(resolved)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	return resolved, true
+}
+
+// isLoopbackHost reports whether host only accepts local connections
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
 
 func (s *liveServer) handleCV(w http.ResponseWriter, r *http.Request) {
@@ -147,7 +561,7 @@ func (s *liveServer) handleCV(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Inject live reload script before </body>
+	// Inject live reload script and toolbar before </body>
 	html := string(content)
 	html = injectLiveReload(html)
 
@@ -160,13 +574,23 @@ func injectLiveReload(html string) string {
 	const bodyClose = "</body>"
 	idx := strings.LastIndex(html, bodyClose)
 	if idx != -1 {
-		return html[:idx] + liveReloadScript + "\n" + html[idx:]
+		return html[:idx] + liveReloadScript + "\n" + liveToolbar + "\n" + errorOverlay + "\n" + html[idx:]
 	}
 	// Fallback: append at the end
-	return html + liveReloadScript
+	return html + liveReloadScript + liveToolbar + errorOverlay
 }
 
 func runServe(cmd *cobra.Command, args []string) {
+	if err := validateBasicAuth(basicAuth); err != nil {
+		log.Fatal("Invalid option", "error", err)
+	}
+	if (tlsCert == "") != (tlsKey == "") {
+		log.Fatal("Invalid option", "error", "--tls-cert and --tls-key go together")
+	}
+	if tlsCert != "" {
+		serveTLS = true
+	}
+
 	if _, err := os.Stat(dataPath); os.IsNotExist(err) {
 		log.Fatal("Data file does not exist", "path", dataPath)
 	}
@@ -180,11 +604,21 @@ func runServe(cmd *cobra.Command, args []string) {
 		outputDir: outputDir,
 		theme:     theme,
 		color:     primaryColor,
+		clients:   make(map[chan string]struct{}),
+		dirs:      make(map[string]bool),
+	}
+
+	if editorMode {
+		token, err := newEditorToken()
+		if err != nil {
+			log.Fatal("Error creating editor token", "error", err)
+		}
+		server.editorToken = token
 	}
 
 	// Initial generation
 	log.Info("Generating initial CV...")
-	if err := server.regenerate(); err != nil {
+	if _, err := server.regenerate(); err != nil {
 		log.Fatal("Error generating CV", "error", err)
 	}
 
@@ -192,27 +626,35 @@ func runServe(cmd *cobra.Command, args []string) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	server.done = ctx.Done()
+
 	// Start file watcher
-	go server.watchFile(ctx)
+	if err := server.watch(ctx); err != nil {
+		log.Fatal("Error watching data file", "error", err)
+	}
 
 	// Setup HTTP handlers
 	mux := http.NewServeMux()
-	mux.HandleFunc("/_reload", server.handleReload)
+	mux.HandleFunc("/_events", server.handleEvents)
+	mux.HandleFunc("/_theme.css", server.handleThemeCSS)
+	mux.HandleFunc("/_api/themes", server.handleThemes)
+	mux.HandleFunc("/_api/settings", server.handleSettings)
+	mux.HandleFunc("/_api/pdf", server.handlePDF)
+	mux.HandleFunc("/_api/error", server.handleError)
+	if editorMode {
+		mux.HandleFunc("/_editor", server.handleEditor)
+		mux.HandleFunc("/_api/source", server.handleSource)
+		mux.HandleFunc("/_api/schema", server.handleSchema)
+	}
 	// Serve all requests through custom handler
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		// Handle reload endpoint
-		if r.URL.Path == "/_reload" {
-			server.handleReload(w, r)
-			return
-		}
 		// Handle root - serve CV
 		if r.URL.Path == "/" {
 			server.handleCV(w, r)
 			return
 		}
 		// Try to serve static file from output directory (photos, etc.)
-		filePath := filepath.Join(outputDir, r.URL.Path)
-		if info, err := os.Stat(filePath); err == nil && !info.IsDir() {
+		if filePath, ok := server.staticFile(r.URL.Path); ok {
 			http.ServeFile(w, r, filePath)
 			return
 		}
@@ -220,9 +662,30 @@ func runServe(cmd *cobra.Command, args []string) {
 		server.handleCV(w, r)
 	})
 
+	access := accessControl{basicAuth: basicAuth, token: serveToken}
+	handler := access.wrap(mux)
+	if isLoopbackHost(serveHost) {
+		handler = localHostOnly(handler)
+	}
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf(":%d", port),
-		Handler: mux,
+		Addr:              net.JoinHostPort(serveHost, strconv.Itoa(port)),
+		Handler:           handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+
+	scheme := "http"
+	if serveTLS {
+		scheme = "https"
+		if tlsCert == "" {
+			cert, err := selfSignedCertificate(certificateHosts(serveHost))
+			if err != nil {
+				log.Fatal("Error creating TLS certificate", "error", err)
+			}
+			httpServer.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+		}
 	}
 
 	// Handle shutdown signals
@@ -236,14 +699,38 @@ func runServe(cmd *cobra.Command, args []string) {
 		httpServer.Shutdown(context.Background())
 	}()
 
-	url := fmt.Sprintf("http://localhost:%d", port)
+	displayHost := serveHost
+	if isWildcardHost(serveHost) {
+		displayHost = "localhost"
+	}
+	url := fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(displayHost, strconv.Itoa(port)))
+	pageURL, editURL := url, editorURL(url, server.editorToken)
+	if serveToken != "" {
+		pageURL += "/?token=" + serveToken
+		editURL += "&token=" + serveToken
+	}
+
+	if !isLoopbackHost(serveHost) && !access.enabled() {
+		log.Warn("The preview is reachable from the network without authentication, use --basic-auth or --token")
+	}
 	log.Info("Starting live preview server", "url", url)
 	log.Info("Watching for changes", "file", dataPath)
 	log.Info("Press Ctrl+C to stop")
 	fmt.Println()
-	fmt.Printf("  🌐 Open in browser: %s\n\n", url)
+	fmt.Printf("  🌐 Open in browser: %s\n", pageURL)
+	if editorMode {
+		fmt.Printf("  ✏️  Edit in browser: %s\n", editURL)
+	}
+	fmt.Println()
 
-	if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
+	var err error
+	if serveTLS {
+		// Empty paths use httpServer.TLSConfig
+		err = httpServer.ListenAndServeTLS(tlsCert, tlsKey)
+	} else {
+		err = httpServer.ListenAndServe()
+	}
+	if err != http.ErrServerClosed {
 		log.Fatal("Server error", "error", err)
 	}
 }
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// accessCookie remembers a valid --token in the browser so that the page,
+// its assets and the event stream do not need it in every URL
+const accessCookie = "resumectl_token"
+
+// accessControl protects the preview with basic auth (user:password) and/or
+// a token, given as a Bearer token, as the token query parameter or through
+// the cookie set after a successful query. Any configured method is enough.
+type accessControl struct {
+	basicAuth string
+	token     string
+}
+
+func (a accessControl) enabled() bool {
+	return a.basicAuth != "" || a.token != ""
+}
+
+// wrap returns next behind the access checks
+func (a accessControl) wrap(next http.Handler) http.Handler {
+	if !a.enabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.token != "" {
+			if query := r.URL.Query().Get("token"); query != "" && secureEqual(query, a.token) {
+				http.SetCookie(w, &http.Cookie{
+					Name:     accessCookie,
+					Value:    a.token,
+					Path:     "/",
+					HttpOnly: true,
+					Secure:   r.TLS != nil,
+					SameSite: http.SameSiteStrictMode,
+				})
+				next.ServeHTTP(w, r)
+				return
+			}
+			if cookie, err := r.Cookie(accessCookie); err == nil && secureEqual(cookie.Value, a.token) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && secureEqual(bearer, a.token) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		if a.basicAuth != "" {
+			if user, password, ok := r.BasicAuth(); ok && secureEqual(user+":"+password, a.basicAuth) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Basic realm="resumectl", charset="UTF-8"`)
+		}
+
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	})
+}
+
+// localHostOnly rejects the requests whose Host header does not name the
+// local machine. Without it, a web page could reach a preview bound to the
+// loopback interface by pointing its own domain name to 127.0.0.1 (DNS
+// rebinding).
+func localHostOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLocalHostHeader(r.Host) {
+			http.Error(w, "Forbidden: unexpected Host header", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLocalHostHeader reports whether a Host header is localhost, 127.0.0.1
+// or ::1, with or without a port
+func isLocalHostHeader(host string) bool {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	switch strings.ToLower(host) {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return false
+}
+
+// validateBasicAuth checks the user:password format of --basic-auth
+func validateBasicAuth(value string) error {
+	if value == "" {
+		return nil
+	}
+	if user, password, ok := strings.Cut(value, ":"); !ok || user == "" || password == "" {
+		return fmt.Errorf("--basic-auth must be user:password")
+	}
+	return nil
+}
+
+// secureEqual compares secrets in constant time
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
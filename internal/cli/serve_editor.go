@@ -0,0 +1,634 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"resumectl"
+	"resumectl/internal/models"
+
+	"github.com/charmbracelet/log"
+	"gopkg.in/yaml.v3"
+)
+
+// maxSourceSize limits the size of a CV saved from the browser editor
+const maxSourceSize = 1 << 20
+
+// newEditorToken returns a random token protecting the editor API
+func newEditorToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// sourceVersion identifies a content of the data file for optimistic
+// concurrency: a save is only accepted for the version it was based on
+func sourceVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// authorized checks the editor token, given in the X-Editor-Token header
+// for API calls or as the editor query parameter for the editor page. It is
+// not sent as Authorization, which --basic-auth may already use.
+func (s *liveServer) authorized(r *http.Request) bool {
+	token := r.Header.Get("X-Editor-Token")
+	if token == "" {
+		token = r.URL.Query().Get("editor")
+	}
+	return token != "" && secureEqual(token, s.editorToken)
+}
+
+// handleEditor serves the editor page
+func (s *liveServer) handleEditor(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "Open the editor with the link printed by resumectl serve", http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprint(w, editorPage)
+}
+
+// handleSchema serves the JSON Schema of the CV file
+func (s *liveServer) handleSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.Write(resumectl.Schema)
+}
+
+// sourceResponse is the content of the data file sent to the editor
+type sourceResponse struct {
+	Content string `json:"content"`
+	Version string `json:"version"`
+}
+
+// handleSource returns the data file on GET and saves it on PUT. A save
+// must carry the version it was based on in If-Match; if the file changed
+// on disk since then, it is refused with 409 and the current content.
+func (s *liveServer) handleSource(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeJSONError(w, http.StatusUnauthorized, fmt.Errorf("invalid or missing editor token"))
+		return
+	}
+
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	current, err := os.ReadFile(s.dataPath)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	version := sourceVersion(current)
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("ETag", `"`+version+`"`)
+		writeJSON(w, http.StatusOK, sourceResponse{Content: string(current), Version: version})
+		return
+	case http.MethodPut:
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		writeJSONError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	if strings.Trim(r.Header.Get("If-Match"), `"`) != version {
+		writeJSON(w, http.StatusConflict, sourceResponse{Content: string(current), Version: version})
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSourceSize))
+	if err != nil {
+		writeJSONError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+
+	// Refuse content the generator could not load
+	var cv models.CV
+	if err := yaml.Unmarshal(data, &cv); err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, newSourceError(err, data))
+		return
+	}
+
+	if err := writeFileAtomic(s.dataPath, data); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	log.Info("CV saved from the editor", "file", s.dataPath)
+
+	version = sourceVersion(data)
+	w.Header().Set("ETag", `"`+version+`"`)
+	writeJSON(w, http.StatusOK, sourceResponse{Content: string(data), Version: version})
+}
+
+// newSourceError describes a YAML error in content that is not on disk
+func newSourceError(err error, data []byte) *buildError {
+	be := &buildError{Message: err.Error()}
+	m := yamlLinePattern.FindStringSubmatch(be.Message)
+	if m == nil {
+		return be
+	}
+	be.Line, _ = strconv.Atoi(m[1])
+	if lines := strings.Split(string(data), "\n"); be.Line >= 1 && be.Line <= len(lines) {
+		be.Column = errorColumn(data, be.Message, lines[be.Line-1], be.Line)
+	}
+	return be
+}
+
+// writeFileAtomic replaces path with data through a temporary file, keeping
+// the file mode, so that readers never see a partial file. A symbolic link
+// is kept: its target is replaced.
+func writeFileAtomic(path string, data []byte) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// editorURL returns the link to the editor, with its token
+func editorURL(base, token string) string {
+	return base + "/_editor?editor=" + token
+}
+
+// editorPage is the split-pane browser editor: the YAML file on the left,
+// with completion of keys and values from the JSON Schema, and the live
+// preview on the right
+const editorPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<title>resumectl editor</title>
+<style>
+* { box-sizing: border-box; }
+html, body { margin: 0; height: 100%; font: 13px -apple-system, 'Segoe UI', sans-serif; }
+body { display: flex; flex-direction: column; }
+header {
+    display: flex; align-items: center; gap: 12px; padding: 6px 12px;
+    background: #111827; color: #f9fafb;
+}
+header .title { font-weight: 600; }
+header .status { flex: 1; color: #9ca3af; white-space: nowrap; overflow: hidden; text-overflow: ellipsis; }
+header .status.error { color: #f87171; }
+header button { font: inherit; border: 0; border-radius: 4px; padding: 4px 10px; background: #2563eb; color: white; cursor: pointer; }
+header button:disabled { background: #4b5563; cursor: default; }
+#conflict { display: none; gap: 8px; align-items: center; padding: 6px 12px; background: #fef3c7; color: #92400e; }
+#conflict.shown { display: flex; }
+#conflict span { flex: 1; }
+#conflict button { font: inherit; border: 1px solid #92400e; border-radius: 4px; background: white; color: #92400e; padding: 2px 8px; cursor: pointer; }
+main { flex: 1; display: flex; min-height: 0; }
+.pane { flex: 1; min-width: 0; display: flex; flex-direction: column; position: relative; }
+.pane + .pane { border-left: 1px solid #e5e7eb; }
+textarea {
+    flex: 1; width: 100%; border: 0; outline: none; resize: none; padding: 12px;
+    font: 13px/1.5 ui-monospace, Menlo, Consolas, monospace; tab-size: 2; white-space: pre;
+}
+iframe { flex: 1; width: 100%; border: 0; }
+#suggestions {
+    display: none; position: absolute; left: 12px; right: 12px; bottom: 12px; max-height: 40%;
+    overflow-y: auto; margin: 0; padding: 4px 0; list-style: none;
+    background: white; border: 1px solid #d1d5db; border-radius: 6px; box-shadow: 0 4px 12px rgba(0, 0, 0, 0.15);
+}
+#suggestions.shown { display: block; }
+#suggestions li { padding: 3px 10px; cursor: pointer; }
+#suggestions li.active { background: #dbeafe; }
+#suggestions li b { font-family: ui-monospace, Menlo, Consolas, monospace; font-weight: 600; }
+#suggestions li small { color: #6b7280; margin-left: 8px; }
+</style>
+</head>
+<body>
+<header>
+    <span class="title">resumectl</span>
+    <span class="status" id="status">Loading...</span>
+    <button id="save" disabled>Save</button>
+</header>
+<div id="conflict">
+    <span>The file was changed on disk since you loaded it.</span>
+    <button id="load-disk">Load the file from disk</button>
+    <button id="overwrite">Keep my version</button>
+</div>
+<main>
+    <div class="pane">
+        <textarea id="source" spellcheck="false" autocomplete="off" disabled></textarea>
+        <ul id="suggestions"></ul>
+    </div>
+    <div class="pane"><iframe id="preview" src="/" title="Preview"></iframe></div>
+</main>
+<script>
+(function() {
+    const params = new URLSearchParams(location.search);
+    if (params.get('editor')) {
+        sessionStorage.setItem('resumectl-token', params.get('editor'));
+        history.replaceState(null, '', location.pathname);
+    }
+    const token = sessionStorage.getItem('resumectl-token');
+
+    const source = document.getElementById('source');
+    const status = document.getElementById('status');
+    const save = document.getElementById('save');
+    const conflict = document.getElementById('conflict');
+    const suggestions = document.getElementById('suggestions');
+
+    let version = '';      // Version of the file the editor content is based on
+    let saved = '';        // Content of that version
+    let diskVersion = '';  // Version on disk when it differs from version
+    let schema = null;
+
+    function setStatus(text, isError) {
+        status.textContent = text;
+        status.className = isError ? 'status error' : 'status';
+    }
+
+    function dirty() {
+        return source.value !== saved;
+    }
+
+    function updateDirty() {
+        save.disabled = !dirty();
+        document.title = (dirty() ? '* ' : '') + 'resumectl editor';
+    }
+
+    function api(method, body, ifMatch) {
+        const headers = {'X-Editor-Token': token};
+        if (ifMatch) {
+            headers['If-Match'] = '"' + ifMatch + '"';
+        }
+        return fetch('/_api/source', {method: method, headers: headers, body: body})
+            .then(r => r.json().then(data => ({status: r.status, data: data})));
+    }
+
+    function load(data) {
+        version = data.version;
+        saved = data.content;
+        source.value = data.content;
+        diskVersion = '';
+        conflict.classList.remove('shown');
+        updateDirty();
+    }
+
+    function fetchSource() {
+        return api('GET').then(res => {
+            if (res.status !== 200) {
+                throw new Error(res.data.error);
+            }
+            return res.data;
+        });
+    }
+
+    function doSave(base) {
+        const content = source.value;
+        setStatus('Saving...');
+        api('PUT', content, base).then(res => {
+            switch (res.status) {
+            case 200:
+                version = res.data.version;
+                saved = content;
+                diskVersion = '';
+                conflict.classList.remove('shown');
+                updateDirty();
+                setStatus('Saved');
+                break;
+            case 409:
+                diskVersion = res.data.version;
+                conflict.classList.add('shown');
+                setStatus('Not saved: the file changed on disk', true);
+                break;
+            case 422:
+                setStatus('Not saved: ' + (res.data.line ? 'line ' + res.data.line + ': ' : '') + res.data.message, true);
+                if (res.data.line) {
+                    goToLine(res.data.line, res.data.column || 1);
+                }
+                break;
+            default:
+                setStatus('Not saved: ' + res.data.error, true);
+            }
+        }).catch(err => setStatus('Not saved: ' + err.message, true));
+    }
+
+    function goToLine(line, column) {
+        const lines = source.value.split('\n');
+        let offset = 0;
+        for (let i = 0; i < line - 1 && i < lines.length; i++) {
+            offset += lines[i].length + 1;
+        }
+        offset += column - 1;
+        source.focus();
+        source.setSelectionRange(offset, offset);
+    }
+
+    save.addEventListener('click', () => doSave(version));
+    document.getElementById('overwrite').addEventListener('click', () => doSave(diskVersion));
+    document.getElementById('load-disk').addEventListener('click', () => {
+        fetchSource().then(data => {
+            load(data);
+            setStatus('Loaded the file from disk');
+        });
+    });
+
+    // Follow changes made on disk by other editors
+    const events = new EventSource('/_events');
+    function onDiskChange() {
+        fetchSource().then(data => {
+            if (data.version === version) {
+                return;
+            }
+            if (!dirty()) {
+                load(data);
+                setStatus('Reloaded: the file changed on disk');
+            } else {
+                diskVersion = data.version;
+                conflict.classList.add('shown');
+            }
+        }).catch(() => {});
+    }
+    events.addEventListener('reload', onDiskChange);
+    events.addEventListener('clear', onDiskChange);
+    events.addEventListener('error', e => { if (e.data) { onDiskChange(); } });
+
+    window.addEventListener('beforeunload', e => {
+        if (dirty()) {
+            e.preventDefault();
+            e.returnValue = '';
+        }
+    });
+
+    // --- Completion from the JSON Schema ---
+
+    // lineInfo splits a YAML line into indentation, list dash and key
+    function lineInfo(line) {
+        const m = /^( *)(- +)?([A-Za-z0-9_]+)?( *:)?(.*)$/.exec(line);
+        return {
+            indent: m[1].length,
+            dash: !!m[2],
+            keyCol: m[1].length + (m[2] ? m[2].length : 0),
+            key: m[4] ? m[3] : '',
+            rest: m[5].trim(),
+            blank: line.trim() === '' || line.trim().startsWith('#')
+        };
+    }
+
+    // pathAt returns the path of the mapping holding the key on line row,
+    // '[]' standing for list items
+    function pathAt(lines, row) {
+        const current = lineInfo(lines[row]);
+        const path = [];
+        let limit = current.keyCol;
+        let allowEqual = false;
+        if (current.dash) {
+            path.unshift('[]');
+            limit = current.indent;
+            allowEqual = true;
+        }
+        for (let i = row - 1; i >= 0 && (limit > 0 || allowEqual); i--) {
+            const l = lineInfo(lines[i]);
+            if (l.blank) {
+                continue;
+            }
+            if (l.dash && l.indent < limit) {
+                if (l.key && l.keyCol < limit && l.rest === '') {
+                    path.unshift(l.key);
+                }
+                path.unshift('[]');
+                limit = l.indent;
+                allowEqual = true;
+                continue;
+            }
+            if (l.key && l.rest === '' && (l.keyCol < limit || (allowEqual && l.keyCol === limit))) {
+                path.unshift(l.key);
+                limit = l.keyCol;
+                allowEqual = false;
+            }
+        }
+        return path;
+    }
+
+    function schemaAt(path) {
+        let node = schema;
+        for (const seg of path) {
+            if (!node) {
+                return null;
+            }
+            node = seg === '[]' ? node.items : (node.properties || {})[seg];
+        }
+        return node;
+    }
+
+    // completions returns the suggestions at the cursor and the length of
+    // the text they replace
+    function completions() {
+        if (!schema) {
+            return null;
+        }
+        const pos = source.selectionStart;
+        const before = source.value.slice(0, pos);
+        const lines = before.split('\n');
+        const row = lines.length - 1;
+        const lineText = lines[row];
+        const all = source.value.split('\n');
+        all[row] = lineText;
+        const info = lineInfo(lineText);
+        const node = schemaAt(pathAt(all, row));
+        if (!node) {
+            return null;
+        }
+
+        const value = /^( *)(- +)?([A-Za-z0-9_]+) *: *(\S*)$/.exec(lineText);
+        if (value) {
+            // Value of a key: enums and booleans
+            const prop = (node.properties || {})[value[3]];
+            if (!prop) {
+                return null;
+            }
+            let values = prop.enum || (prop.type === 'boolean' ? [true, false] : []);
+            values = values.map(String).filter(v => v.startsWith(value[4]));
+            return {replace: value[4].length, items: values.map(v => ({label: v, insert: v, detail: ''}))};
+        }
+
+        const key = /^( *)(- +)?([A-Za-z0-9_]*)$/.exec(lineText);
+        if (!key || !node.properties) {
+            return null;
+        }
+        const prefix = key[3].toLowerCase();
+        const items = Object.keys(node.properties)
+            .filter(k => k.toLowerCase().startsWith(prefix))
+            .map(k => {
+                const prop = node.properties[k];
+                let insert = k + ': ';
+                if (prop.type === 'object') {
+                    insert = k + ':\n' + ' '.repeat(info.keyCol + 2);
+                } else if (prop.type === 'array') {
+                    insert = k + ':\n' + ' '.repeat(info.keyCol + 2) + '- ';
+                }
+                return {label: k, insert: insert, detail: prop.description || ''};
+            });
+        return {replace: key[3].length, items: items};
+    }
+
+    let current = null;
+    let active = 0;
+
+    function showSuggestions() {
+        current = completions();
+        suggestions.replaceChildren();
+        if (!current || current.items.length === 0) {
+            hideSuggestions();
+            return;
+        }
+        active = Math.min(active, current.items.length - 1);
+        current.items.forEach((item, i) => {
+            const li = document.createElement('li');
+            const label = document.createElement('b');
+            label.textContent = item.label;
+            li.appendChild(label);
+            if (item.detail) {
+                const detail = document.createElement('small');
+                detail.textContent = item.detail;
+                li.appendChild(detail);
+            }
+            if (i === active) {
+                li.className = 'active';
+            }
+            li.addEventListener('mousedown', e => {
+                e.preventDefault();
+                accept(i);
+            });
+            suggestions.appendChild(li);
+        });
+        suggestions.classList.add('shown');
+        suggestions.children[active].scrollIntoView({block: 'nearest'});
+    }
+
+    function hideSuggestions() {
+        current = null;
+        active = 0;
+        suggestions.classList.remove('shown');
+    }
+
+    function accept(i) {
+        const item = current.items[i];
+        const pos = source.selectionStart;
+        source.setRangeText(item.insert, pos - current.replace, pos, 'end');
+        hideSuggestions();
+        updateDirty();
+    }
+
+    source.addEventListener('keydown', e => {
+        if ((e.ctrlKey || e.metaKey) && e.key === 's') {
+            e.preventDefault();
+            if (dirty()) {
+                doSave(version);
+            }
+            return;
+        }
+        if (e.ctrlKey && e.key === ' ') {
+            e.preventDefault();
+            showSuggestions();
+            return;
+        }
+        if (current) {
+            switch (e.key) {
+            case 'ArrowDown':
+                e.preventDefault();
+                active = (active + 1) % current.items.length;
+                showSuggestions();
+                return;
+            case 'ArrowUp':
+                e.preventDefault();
+                active = (active + current.items.length - 1) % current.items.length;
+                showSuggestions();
+                return;
+            case 'Enter':
+            case 'Tab':
+                e.preventDefault();
+                accept(active);
+                return;
+            case 'Escape':
+                e.preventDefault();
+                hideSuggestions();
+                return;
+            }
+        }
+        if (e.key === 'Tab') {
+            e.preventDefault();
+            source.setRangeText('  ', source.selectionStart, source.selectionEnd, 'end');
+            updateDirty();
+        }
+    });
+
+    source.addEventListener('input', e => {
+        updateDirty();
+        // Suggest keys while typing one at the start of a line
+        const before = source.value.slice(0, source.selectionStart);
+        const line = before.slice(before.lastIndexOf('\n') + 1);
+        if (current || (e.data && /^ *(- +)?[A-Za-z0-9_]$/.test(line))) {
+            showSuggestions();
+        }
+    });
+    source.addEventListener('blur', hideSuggestions);
+    source.addEventListener('click', hideSuggestions);
+
+    Promise.all([fetchSource(), fetch('/_api/schema').then(r => r.json())])
+        .then(([data, s]) => {
+            schema = s;
+            load(data);
+            source.disabled = false;
+            source.focus();
+            setStatus('Ctrl+Space to complete, Ctrl+S to save');
+        })
+        .catch(err => setStatus('Cannot load the CV: ' + err.message, true));
+})();
+</script>
+</body>
+</html>
+`
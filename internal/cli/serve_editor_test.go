@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+
+	// A new file gets the default mode
+	path := filepath.Join(dir, "cv.yaml")
+	if err := writeFileAtomic(path, []byte("a: 1\n")); err != nil {
+		t.Fatalf("writeFileAtomic: %v", err)
+	}
+
+	// The mode of an existing file is kept
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(path, []byte("a: 2\n")); err != nil {
+		t.Fatalf("writeFileAtomic: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "a: 2\n" {
+		t.Errorf("content = %q, want the new one", data)
+	}
+	if info, _ := os.Stat(path); runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A symbolic link stays a link to the updated target
+	link := filepath.Join(dir, "link.yaml")
+	if err := os.Symlink(path, link); err != nil {
+		t.Skipf("symbolic links not supported: %v", err)
+	}
+	if err := writeFileAtomic(link, []byte("a: 3\n")); err != nil {
+		t.Fatalf("writeFileAtomic: %v", err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("link replaced by a regular file")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "a: 3\n" {
+		t.Errorf("target content = %q, want the new one", data)
+	}
+
+	// No temporary file is left behind
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("directory holds %d entries, want cv.yaml and link.yaml", len(entries))
+	}
+}
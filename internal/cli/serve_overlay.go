@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// snippetContext is the number of lines shown around the offending one
+const snippetContext = 2
+
+// yamlLinePattern finds the line number in yaml.v3 error messages
+var yamlLinePattern = regexp.MustCompile(`line (\d+):`)
+
+// errorOverlay injects the overlay displaying generation errors
+const errorOverlay = `<style>
+#resumectl-error {
+    position: fixed; inset: 0; z-index: 10000; overflow: auto;
+    padding: 40px; background: rgba(17, 24, 39, 0.92); color: #f9fafb;
+    font: 14px -apple-system, 'Segoe UI', sans-serif;
+}
+#resumectl-error h2 { color: #f87171; margin-bottom: 12px; font-size: 18px; }
+#resumectl-error .message { white-space: pre-wrap; margin-bottom: 16px; }
+#resumectl-error pre {
+    font: 13px/1.5 ui-monospace, Menlo, Consolas, monospace;
+    background: #111827; padding: 12px 0; border-radius: 6px; overflow-x: auto;
+}
+#resumectl-error pre span { display: block; padding: 0 12px; white-space: pre; }
+#resumectl-error pre span.error { background: rgba(248, 113, 113, 0.25); }
+#resumectl-error pre span.caret { color: #f87171; }
+#resumectl-error .hint { margin-top: 16px; color: #9ca3af; }
+@media print { #resumectl-error { display: none; } }
+</style>
+<script>
+function showErrorOverlay(err) {
+    let overlay = document.getElementById('resumectl-error');
+    if (!overlay) {
+        overlay = document.createElement('div');
+        overlay.id = 'resumectl-error';
+        document.body.appendChild(overlay);
+    }
+    overlay.replaceChildren();
+
+    const title = document.createElement('h2');
+    title.textContent = 'Error in ' + err.file + (err.line ? ':' + err.line + (err.column ? ':' + err.column : '') : '');
+    overlay.appendChild(title);
+
+    const message = document.createElement('div');
+    message.className = 'message';
+    message.textContent = err.message;
+    overlay.appendChild(message);
+
+    if (err.snippet && err.snippet.length) {
+        const pre = document.createElement('pre');
+        const width = String(err.snippet[err.snippet.length - 1].number).length;
+        for (const line of err.snippet) {
+            const span = document.createElement('span');
+            span.textContent = String(line.number).padStart(width) + ' | ' + line.text;
+            if (line.number === err.line) {
+                span.className = 'error';
+            }
+            pre.appendChild(span);
+            if (line.number === err.line && err.column) {
+                const caret = document.createElement('span');
+                caret.className = 'caret';
+                caret.textContent = ' '.repeat(width) + ' | ' + ' '.repeat(err.column - 1) + '^';
+                pre.appendChild(caret);
+            }
+        }
+        overlay.appendChild(pre);
+    }
+
+    const hint = document.createElement('div');
+    hint.className = 'hint';
+    hint.textContent = 'The preview updates as soon as the file is fixed.';
+    overlay.appendChild(hint);
+}
+</script>`
+
+// buildError describes a failed generation for the browser overlay
+type buildError struct {
+	Message string        `json:"message"`
+	File    string        `json:"file"`
+	Line    int           `json:"line,omitempty"`
+	Column  int           `json:"column,omitempty"`
+	Snippet []snippetLine `json:"snippet,omitempty"`
+}
+
+// snippetLine is a numbered line of the data file
+type snippetLine struct {
+	Number int    `json:"number"`
+	Text   string `json:"text"`
+}
+
+// newBuildError locates err in the data file when it is a YAML error
+// mentioning a line, and extracts the lines around it
+func newBuildError(err error, path string) *buildError {
+	be := &buildError{Message: err.Error(), File: path}
+
+	m := yamlLinePattern.FindStringSubmatch(be.Message)
+	if m == nil {
+		return be
+	}
+	be.Line, _ = strconv.Atoi(m[1])
+
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return be
+	}
+	lines := strings.Split(string(data), "\n")
+	if be.Line < 1 || be.Line > len(lines) {
+		return be
+	}
+
+	be.Column = errorColumn(data, be.Message, lines[be.Line-1], be.Line)
+
+	first := max(be.Line-snippetContext, 1)
+	last := min(be.Line+snippetContext, len(lines))
+	for n := first; n <= last; n++ {
+		be.Snippet = append(be.Snippet, snippetLine{Number: n, Text: strings.TrimRight(lines[n-1], "\r")})
+	}
+	return be
+}
+
+// errorColumn returns the column of the error on line. yaml.v3 only reports
+// lines: for a document that parses (type errors) the column of the key on
+// the line is used, or of its value when the value has the wrong type;
+// otherwise (syntax errors, usually indentation) the first non-blank
+// character of the line.
+func errorColumn(data []byte, message, line string, number int) int {
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) == nil {
+		if key, value := findPair(&doc, number); key != nil {
+			if strings.Contains(message, "cannot unmarshal") && value.Line == number {
+				return value.Column
+			}
+			return key.Column
+		}
+	}
+	return len(line) - len(strings.TrimLeft(line, " \t")) + 1
+}
+
+// findPair returns the first mapping key starting on line and its value
+func findPair(node *yaml.Node, line int) (key, value *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Line == line {
+				return node.Content[i], node.Content[i+1]
+			}
+		}
+	}
+	for _, child := range node.Content {
+		if key, value = findPair(child, line); key != nil {
+			return key, value
+		}
+	}
+	return nil, nil
+}
+
+// handleError returns the error of the last generation, or null
+func (s *liveServer) handleError(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	be := s.buildErr
+	s.mu.RUnlock()
+
+	writeJSON(w, http.StatusOK, be)
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"resumectl/internal/generator"
+	"resumectl/internal/templates"
+
+	"github.com/charmbracelet/log"
+)
+
+// liveToolbar injects the preview toolbar. It reads the themes and settings
+// from the JSON API and posts changes back; the server regenerates the page
+// and pushes a "css" event, so switching theme or color does not reload it.
+const liveToolbar = `<style>
+#resumectl-toolbar {
+    position: fixed; top: 12px; right: 12px; z-index: 9999;
+    display: flex; gap: 8px; align-items: center;
+    padding: 6px 10px; border-radius: 6px;
+    background: rgba(17, 24, 39, 0.85); color: #f9fafb;
+    font: 13px -apple-system, 'Segoe UI', sans-serif;
+    box-shadow: 0 2px 8px rgba(0, 0, 0, 0.25);
+}
+#resumectl-toolbar select, #resumectl-toolbar button, #resumectl-toolbar a {
+    font: inherit; color: #111827; background: #f9fafb;
+    border: 0; border-radius: 4px; padding: 2px 6px; text-decoration: none;
+}
+#resumectl-toolbar input[type=color] {
+    width: 28px; height: 22px; padding: 0; border: 0; background: none;
+}
+@media print { #resumectl-toolbar { display: none; } }
+</style>
+<div id="resumectl-toolbar">
+    <select id="resumectl-theme" title="Theme"></select>
+    <input id="resumectl-color" type="color" title="Primary color">
+    <button id="resumectl-reset" type="button" title="Use the theme color">Reset</button>
+    <a id="resumectl-pdf" href="/_api/pdf" title="Download as PDF">PDF</a>
+</div>
+<script>
+(function() {
+    const theme = document.getElementById('resumectl-theme');
+    const color = document.getElementById('resumectl-color');
+    const pdf = document.getElementById('resumectl-pdf');
+
+    function show(settings) {
+        theme.value = settings.theme;
+        color.value = settings.color || settings.themeColor || '#000000';
+        pdf.href = '/_api/pdf?' + new URLSearchParams({theme: settings.theme, color: settings.color});
+    }
+
+    function update(changes) {
+        fetch('/_api/settings', {
+            method: 'POST',
+            headers: {'Content-Type': 'application/json'},
+            body: JSON.stringify(changes)
+        })
+            .then(r => r.json())
+            .then(settings => {
+                if (settings.error) {
+                    alert(settings.error);
+                    return;
+                }
+                show(settings);
+            });
+    }
+
+    Promise.all([fetch('/_api/themes').then(r => r.json()), fetch('/_api/settings').then(r => r.json())])
+        .then(([themes, settings]) => {
+            for (const t of themes) {
+                const option = document.createElement('option');
+                option.value = t.name;
+                option.textContent = t.name;
+                option.title = t.description;
+                theme.appendChild(option);
+            }
+            show(settings);
+        });
+
+    theme.addEventListener('change', () => update({theme: theme.value}));
+    color.addEventListener('change', () => update({color: color.value}));
+    document.getElementById('resumectl-reset').addEventListener('click', () => update({color: ''}));
+})();
+</script>`
+
+// previewSettings are the rendering settings the toolbar can change
+type previewSettings struct {
+	Theme      string `json:"theme"`
+	Color      string `json:"color"`
+	ThemeColor string `json:"themeColor,omitempty"` // Primary color of the theme, for the color picker
+}
+
+// settings returns the current rendering settings
+func (s *liveServer) settings() previewSettings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return previewSettings{Theme: s.theme, Color: s.color, ThemeColor: templates.GetThemePrimaryColor(s.theme)}
+}
+
+// handleThemes lists the available themes
+func (s *liveServer) handleThemes(w http.ResponseWriter, r *http.Request) {
+	type themeInfo struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+
+	themes := make([]themeInfo, 0, len(templates.AvailableThemes))
+	for name, t := range templates.AvailableThemes {
+		themes = append(themes, themeInfo{Name: name, Description: t.Description})
+	}
+	sort.Slice(themes, func(i, j int) bool { return themes[i].Name < themes[j].Name })
+
+	writeJSON(w, http.StatusOK, themes)
+}
+
+// handleSettings returns the rendering settings on GET. On POST it applies
+// the theme and/or color given as JSON, regenerates the CV and notifies the
+// browsers. An empty color restores the theme's own.
+func (s *liveServer) handleSettings(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.settings())
+		return
+	case http.MethodPost:
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeJSONError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	if !isJSONRequest(r) {
+		writeJSONError(w, http.StatusUnsupportedMediaType, fmt.Errorf("the request body must be JSON (Content-Type: application/json)"))
+		return
+	}
+
+	var changes struct {
+		Theme *string `json:"theme"`
+		Color *string `json:"color"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&changes); err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+		return
+	}
+
+	current := s.settings()
+	if changes.Theme != nil {
+		current.Theme = *changes.Theme
+	}
+	if changes.Color != nil {
+		current.Color = *changes.Color
+	}
+	if err := validateSettings(current.Theme, current.Color); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.mu.Lock()
+	s.theme, s.color = current.Theme, current.Color
+	s.mu.Unlock()
+
+	event, err := s.regenerate()
+	if err != nil {
+		log.Error("Error regenerating", "error", err)
+		s.broadcast(event)
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	log.Info("Preview settings changed", "theme", current.Theme, "color", current.Color)
+	if event != "" {
+		s.broadcast(event)
+	}
+
+	writeJSON(w, http.StatusOK, s.settings())
+}
+
+// handlePDF renders the CV as PDF with the theme and color given as query
+// parameters (defaulting to the current settings) and sends it as a download
+func (s *liveServer) handlePDF(w http.ResponseWriter, r *http.Request) {
+	settings := s.settings()
+	query := r.URL.Query()
+	if query.Has("theme") {
+		settings.Theme = query.Get("theme")
+	}
+	if query.Has("color") {
+		settings.Color = query.Get("color")
+	}
+	if err := validateSettings(settings.Theme, settings.Color); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Render in a scratch directory so that the preview is left untouched
+	tmpDir, err := os.MkdirTemp("", "resumectl-pdf-")
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer os.RemoveAll(tmpDir)
+
+	gen, err := generator.NewWithColor(s.dataPath, settings.Theme, settings.Color, tmpDir)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	gen.SetStructuredData(!noStructuredData)
+	htmlPath := filepath.Join(tmpDir, "cv.html")
+	pdfPath := filepath.Join(tmpDir, "cv.pdf")
+	if err := gen.GenerateHTML(htmlPath); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if err := gen.GeneratePDF(htmlPath, pdfPath); err != nil {
+		log.Error("Error generating PDF", "error", err)
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="cv-%s.pdf"`, settings.Theme))
+	http.ServeFile(w, r, pdfPath)
+}
+
+// validateSettings checks a theme and color before rendering with them
+func validateSettings(theme, color string) error {
+	if _, ok := templates.AvailableThemes[theme]; !ok {
+		return fmt.Errorf("theme '%s' not found. Available themes: %s", theme, templates.GetThemeNames())
+	}
+	if !templates.ValidateHexColor(color) {
+		return fmt.Errorf("invalid hex color: %s (use format #RRGGBB)", color)
+	}
+	return nil
+}
+
+// isJSONRequest reports whether a request body is declared as JSON. Other
+// sites can only send such a request after a CORS preflight, which the
+// preview never grants, unlike a plain form post.
+func isJSONRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
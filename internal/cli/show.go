@@ -272,6 +272,9 @@ func generateMarkdown(cv *models.CV) string {
 		buf.WriteString("## Projects\n\n")
 		for _, proj := range cv.Projects {
 			buf.WriteString(fmt.Sprintf("### %s\n", proj.Name))
+			if proj.Stars > 0 || proj.Forks > 0 {
+				buf.WriteString(fmt.Sprintf("★ %d · ⑂ %d\n\n", proj.Stars, proj.Forks))
+			}
 			buf.WriteString(fmt.Sprintf("%s\n\n", proj.Description))
 			if len(proj.Technologies) > 0 {
 				buf.WriteString(fmt.Sprintf("*Technologies:* %s\n\n", strings.Join(proj.Technologies, ", ")))
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"os"
+
+	"resumectl/internal/privacy"
+	"resumectl/internal/site"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	siteURL    string
+	siteNoPDF  bool
+	siteNoDOCX bool
+)
+
+var siteCmd = &cobra.Command{
+	Use:   "site",
+	Short: "Generate a static website from the CV",
+	Long: `Generate a small static website from the CV, ready to be published
+on GitHub Pages or any static host:
+
+  index.html              the themed CV, with Open Graph metadata
+  projects/               a page per project and a list of them
+  cv.pdf, cv.docx         downloadable versions of the CV
+  favicon.svg             the initials on the theme color
+  sitemap.xml, robots.txt when --url is given
+
+The site is written to ./site unless --output is given. The fields and
+entries marked private in the CV are left out; --redact removes more.
+
+Usage examples:
+  resumectl site                                   # Generate into ./site
+  resumectl site --url https://jdoe.github.io/     # Absolute links and sitemap
+  resumectl site -o docs --theme elegant           # GitHub Pages from /docs
+  resumectl site --no-pdf                          # Without a PDF tool installed
+  resumectl site --redact phone --obfuscate-email  # Keep contact details from scrapers`,
+	Run: runSite,
+}
+
+func init() {
+	rootCmd.AddCommand(siteCmd)
+	siteCmd.Flags().StringVar(&siteURL, "url", "", "URL the site is published at (for the sitemap and social previews)")
+	siteCmd.Flags().BoolVar(&siteNoPDF, "no-pdf", false, "Do not render the PDF version")
+	siteCmd.Flags().BoolVar(&siteNoDOCX, "no-docx", false, "Do not write the DOCX version")
+	addPrivacyFlags(siteCmd)
+}
+
+func runSite(cmd *cobra.Command, args []string) {
+	if _, err := os.Stat(dataPath); os.IsNotExist(err) {
+		log.Fatal("Data file does not exist", "path", dataPath)
+	}
+
+	dir := outputDir
+	if !cmd.Flags().Changed("output") {
+		dir = "site"
+	}
+	if siteURL == "" {
+		log.Warn("No --url given: the sitemap is skipped and links in social previews stay relative")
+	}
+
+	redacted, err := privacy.ParseFields(redactFields)
+	if err != nil {
+		log.Fatal("Invalid --redact", "error", err)
+	}
+
+	log.Info("Generating website", "dir", dir, "theme", theme)
+	files, err := site.Build(dataPath, dir, site.Options{
+		Theme:   theme,
+		Color:   primaryColor,
+		BaseURL: siteURL,
+		PDF:     !siteNoPDF,
+		DOCX:    !siteNoDOCX,
+
+		StructuredData: !noStructuredData,
+		Redact:         redacted,
+		ObfuscateEmail: obfuscateEmail,
+	})
+	if err != nil {
+		log.Error("Error generating website", "error", err)
+		if !siteNoPDF {
+			log.Info("Tip: use --no-pdf if no PDF tool (Chrome, wkhtmltopdf, WeasyPrint) is installed")
+		}
+		os.Exit(1)
+	}
+
+	for _, file := range files {
+		log.Debug("Written", "file", file)
+	}
+	log.Info("Website generated", "dir", dir, "files", len(files))
+}
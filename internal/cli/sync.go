@@ -0,0 +1,260 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"resumectl/internal/forge"
+	"resumectl/internal/merge"
+	"resumectl/internal/models"
+	"resumectl/internal/skills"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	syncYes    bool
+	syncDryRun bool
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Merge fresh LinkedIn/GitHub data into an existing CV",
+	Long: `Fetch fresh data from LinkedIn and/or GitHub (or GitLab, Gitea, Forgejo and
+Bitbucket) and merge it into an existing
+CV YAML file without losing your hand edits.
+
+The merge works field by field: experiences are matched by company and start
+date, projects by URL. Values you edited by hand are always kept; values you
+did not touch are updated. A snapshot of the last import is stored next to
+the CV file (.<name>.sync.yaml) to tell both apart. Comments and key order
+are preserved.
+
+The changes are shown as a diff and applied after confirmation.
+
+Usage examples:
+  resumectl sync --github juhnny5                      # Add new top projects
+  resumectl sync --linkedin johndoe                    # Update from LinkedIn
+  resumectl sync -d my-cv.yaml --github juhnny5 --yes  # Apply without asking
+  resumectl sync --github juhnny5 --dry-run            # Only show the diff
+  resumectl sync --forge forgejo:https://git.example.com/jdoe  # Projects from Forgejo`,
+	Run: runSync,
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	addImportFlags(syncCmd)
+	syncCmd.Flags().BoolVarP(&syncYes, "yes", "y", false, "Apply changes without confirmation")
+	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show the changes without writing the file")
+}
+
+func runSync(cmd *cobra.Command, args []string) {
+	if !hasImports() {
+		log.Fatal("Nothing to sync. Use --linkedin, --github, --gitlab or --forge")
+	}
+
+	original, err := os.ReadFile(dataPath)
+	if err != nil {
+		log.Fatal("Error reading CV file", "path", dataPath, "error", err)
+	}
+
+	preamble, body := merge.SplitPreamble(original)
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		log.Fatal("Error parsing CV file", "path", dataPath, "error", err)
+	}
+
+	setupCache()
+	theirs, err := fetchImportedCV()
+	if err != nil {
+		log.Fatal("Error fetching data", "error", err)
+	}
+
+	base, err := readSyncBase(dataPath)
+	if err != nil {
+		log.Warn("Ignoring unreadable import snapshot", "error", err)
+	}
+	if base == nil {
+		log.Info("No previous import found: only empty fields and new entries will be added")
+	}
+
+	// The snapshot keeps the data of the sources not fetched this run
+	snapshot := merge.Fold(base, theirs, merge.Sources{
+		LinkedIn: linkedinURL != "",
+		Projects: githubUsername != "" || gitlabUsername != "" || len(forgeSpecs) > 0,
+	})
+
+	// Derived skills already listed under another category are not proposed again
+	proposed := *theirs
+	var current models.CV
+	if err := doc.Decode(&current); err == nil {
+		proposed.Skills = skills.Missing(current.Skills, theirs.Skills)
+	}
+
+	result, err := merge.CV(&doc, base, &proposed)
+	if err != nil {
+		log.Fatal("Error merging data", "error", err)
+	}
+
+	for _, path := range result.Conflicts {
+		log.Warn("Edited locally and upstream, keeping local value", "field", path)
+	}
+
+	if !result.HasChanges() {
+		log.Info("CV is already up to date", "path", dataPath)
+		// A dry run leaves the import snapshot, the base of the next merge,
+		// untouched too
+		if !syncDryRun {
+			saveSyncBase(snapshot)
+		}
+		return
+	}
+
+	encoded, err := merge.Encode(&doc, merge.DetectIndent(body))
+	if err != nil {
+		log.Fatal("Error encoding CV", "error", err)
+	}
+	updated := append(preamble, merge.RestoreBlankLines(body, encoded)...)
+
+	fmt.Println()
+	fmt.Print(merge.Diff(string(original), string(updated)))
+	fmt.Println()
+	log.Info("Merge summary", "updated", len(result.Updated), "added", len(result.Added), "conflicts", len(result.Conflicts))
+
+	if syncDryRun {
+		log.Info("Dry run: no changes written")
+		return
+	}
+
+	if !syncYes && !confirm(fmt.Sprintf("Apply these changes to %s?", dataPath)) {
+		log.Info("Aborted, no changes written")
+		return
+	}
+
+	if err := os.WriteFile(dataPath, updated, 0644); err != nil {
+		log.Fatal("Error writing CV file", "error", err)
+	}
+	saveSyncBase(snapshot)
+
+	log.Info("CV synchronized successfully!", "path", dataPath)
+}
+
+// fetchImportedCV builds a CV holding only the freshly imported data
+func fetchImportedCV() (*models.CV, error) {
+	cv := &models.CV{}
+
+	if linkedinURL != "" {
+		imported, err := fetchLinkedInCV(linkedinURL, linkedInCookie())
+		if err != nil {
+			return nil, fmt.Errorf("LinkedIn: %w", err)
+		}
+		cv = imported
+
+		// LinkedIn never provides these: ToCV only fills in placeholders
+		cv.Personal.Email = ""
+		cv.Personal.Phone = ""
+	}
+
+	var imported []forge.Project
+	if githubUsername != "" {
+		username, projects, err := fetchGitHubProjects(githubUsername, githubProjects)
+		if err != nil {
+			return nil, fmt.Errorf("GitHub: %w", err)
+		}
+		imported = append(imported, projects...)
+		cv.Personal.GitHub = "github.com/" + username
+	}
+
+	if gitlabUsername != "" || len(forgeSpecs) > 0 {
+		projects, err := fetchForgeImports()
+		if err != nil {
+			return nil, err
+		}
+		imported = append(imported, projects...)
+	}
+
+	cv.Projects = append(cv.Projects, toCVProjects(imported)...)
+	importSkills(cv, imported)
+
+	return cv, nil
+}
+
+func saveSyncBase(cv *models.CV) {
+	if err := writeSyncBase(cv, dataPath); err != nil {
+		log.Warn("Could not save import snapshot", "error", err)
+	}
+}
+
+// syncBasePath returns the path of the import snapshot for a CV file
+// (e.g. cv.yaml -> .cv.sync.yaml)
+func syncBasePath(cvPath string) string {
+	dir, name := filepath.Split(cvPath)
+	ext := filepath.Ext(name)
+	return filepath.Join(dir, "."+strings.TrimSuffix(name, ext)+".sync"+ext)
+}
+
+// syncBase renders the import snapshot of a CV
+func syncBase(cv *models.CV) ([]byte, error) {
+	data, err := yaml.Marshal(cv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	header := "# Last data imported by resumectl, used by \"resumectl sync\" to detect hand edits.\n# Do not edit.\n"
+	return append([]byte(header), data...), nil
+}
+
+// writeSyncBase stores the data produced by an import next to the CV file
+func writeSyncBase(cv *models.CV, cvPath string) error {
+	data, err := syncBase(cv)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(syncBasePath(cvPath), data, 0644)
+}
+
+// readSyncBase loads the import snapshot of a CV file, or nil if there is none
+func readSyncBase(cvPath string) (*models.CV, error) {
+	data, err := os.ReadFile(syncBasePath(cvPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cv models.CV
+	if err := yaml.Unmarshal(data, &cv); err != nil {
+		return nil, err
+	}
+	return &cv, nil
+}
+
+// confirm asks a yes/no question on the terminal, defaulting to no
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
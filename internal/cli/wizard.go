@@ -0,0 +1,409 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"regexp"
+	"strings"
+
+	"resumectl/internal/models"
+
+	"github.com/charmbracelet/huh"
+	"golang.org/x/term"
+)
+
+// errWizardQuit is returned when the user leaves the wizard without saving
+var errWizardQuit = errors.New("wizard cancelled")
+
+var (
+	datePattern    = regexp.MustCompile(`^\d{4}(-\d{2})?$`)
+	endDatePattern = regexp.MustCompile(`^(\d{4}(-\d{2})?|present|présent)$`)
+)
+
+// wizardSection is a CV section edited by the wizard
+type wizardSection struct {
+	name string
+	edit func(cv *models.CV) error
+}
+
+var wizardSections = []wizardSection{
+	{"Personal information", editPersonal},
+	{"Experience", editExperiences},
+	{"Education", editEducation},
+	{"Skills", editSkills},
+	{"Languages", editLanguages},
+}
+
+// runWizard walks through the CV sections in the terminal, starting from cv,
+// then lets the user preview the result and revisit sections before saving.
+// It returns errWizardQuit if the user leaves without saving.
+func runWizard(cv *models.CV) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("--interactive needs a terminal")
+	}
+
+	for _, section := range wizardSections {
+		if err := section.edit(cv); err != nil {
+			return wizardError(err)
+		}
+	}
+
+	for {
+		fmt.Println()
+		if err := renderWithGlamour(generateMarkdown(cv)); err != nil {
+			return err
+		}
+
+		choice := -1
+		options := []huh.Option[int]{huh.NewOption("Save", -1)}
+		for i, section := range wizardSections {
+			options = append(options, huh.NewOption("Edit: "+section.name, i))
+		}
+		options = append(options, huh.NewOption("Quit without saving", -2))
+
+		err := huh.NewSelect[int]().
+			Title("Your CV is ready").
+			Options(options...).
+			Value(&choice).
+			Run()
+		if err != nil {
+			return wizardError(err)
+		}
+
+		switch choice {
+		case -1:
+			return nil
+		case -2:
+			return errWizardQuit
+		}
+		if err := wizardSections[choice].edit(cv); err != nil {
+			return wizardError(err)
+		}
+	}
+}
+
+func wizardError(err error) error {
+	if errors.Is(err, huh.ErrUserAborted) {
+		return errWizardQuit
+	}
+	return err
+}
+
+func editPersonal(cv *models.CV) error {
+	p := &cv.Personal
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().Title("Personal information"),
+			huh.NewInput().Title("First name").Value(&p.FirstName).Validate(required("first name")),
+			huh.NewInput().Title("Last name").Value(&p.LastName).Validate(required("last name")),
+			huh.NewInput().Title("Professional title").Placeholder("Full Stack Developer").
+				Value(&p.Title).Validate(required("title")),
+			huh.NewInput().Title("Email").Value(&p.Email).Validate(validateEmail),
+			huh.NewInput().Title("Phone").Value(&p.Phone),
+			huh.NewInput().Title("Location").Placeholder("Paris, France").Value(&p.Location),
+		),
+		huh.NewGroup(
+			huh.NewInput().Title("LinkedIn").Placeholder("linkedin.com/in/yourprofile").Value(&p.LinkedIn),
+			huh.NewInput().Title("GitHub").Placeholder("github.com/yourusername").Value(&p.GitHub),
+			huh.NewInput().Title("Website").Value(&p.Website),
+			huh.NewText().Title("Professional summary").Value(&cv.Summary),
+		),
+	).Run()
+}
+
+func editExperiences(cv *models.CV) error {
+	return editList("experience", len(cv.Experience),
+		func(i int) string {
+			return fmt.Sprintf("%s @ %s (%s)", cv.Experience[i].Position, cv.Experience[i].Company, cv.Experience[i].StartDate)
+		},
+		func(i int) error {
+			if i == len(cv.Experience) {
+				var exp models.Experience
+				if err := runExperienceForm(&exp); err != nil {
+					return err
+				}
+				cv.Experience = append(cv.Experience, exp)
+				return nil
+			}
+			return runExperienceForm(&cv.Experience[i])
+		},
+		func(i int) {
+			cv.Experience = append(cv.Experience[:i], cv.Experience[i+1:]...)
+		},
+	)
+}
+
+func runExperienceForm(exp *models.Experience) error {
+	highlights := strings.Join(exp.Highlights, "\n")
+	err := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().Title("Company").Value(&exp.Company).Validate(required("company")),
+			huh.NewInput().Title("Position").Value(&exp.Position).Validate(required("position")),
+			huh.NewInput().Title("Location").Value(&exp.Location),
+			huh.NewInput().Title("Start date").Placeholder("YYYY-MM").Value(&exp.StartDate).Validate(validateDate(true)),
+			huh.NewInput().Title("End date").Placeholder("YYYY-MM or present").Value(&exp.EndDate).Validate(validateEndDate),
+		),
+		huh.NewGroup(
+			huh.NewText().Title("Description").Value(&exp.Description),
+			huh.NewText().Title("Highlights").Description("One per line").Value(&highlights),
+		),
+	).Run()
+	if err != nil {
+		return err
+	}
+
+	exp.Highlights = splitLines(highlights)
+	return nil
+}
+
+func editEducation(cv *models.CV) error {
+	return editList("education", len(cv.Education),
+		func(i int) string {
+			return fmt.Sprintf("%s, %s", cv.Education[i].Degree, cv.Education[i].Institution)
+		},
+		func(i int) error {
+			if i == len(cv.Education) {
+				var edu models.Education
+				if err := runEducationForm(&edu); err != nil {
+					return err
+				}
+				cv.Education = append(cv.Education, edu)
+				return nil
+			}
+			return runEducationForm(&cv.Education[i])
+		},
+		func(i int) {
+			cv.Education = append(cv.Education[:i], cv.Education[i+1:]...)
+		},
+	)
+}
+
+func runEducationForm(edu *models.Education) error {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().Title("Institution").Value(&edu.Institution).Validate(required("institution")),
+			huh.NewInput().Title("Degree").Placeholder("Master's Degree").Value(&edu.Degree).Validate(required("degree")),
+			huh.NewInput().Title("Field of study").Value(&edu.Field),
+			huh.NewInput().Title("Location").Value(&edu.Location),
+			huh.NewInput().Title("Start date").Placeholder("YYYY").Value(&edu.StartDate).Validate(validateDate(false)),
+			huh.NewInput().Title("End date").Placeholder("YYYY or present").Value(&edu.EndDate).Validate(validateEndDate),
+			huh.NewText().Title("Description").Value(&edu.Description),
+		),
+	).Run()
+}
+
+func editSkills(cv *models.CV) error {
+	return editList("skill category", len(cv.Skills),
+		func(i int) string {
+			return fmt.Sprintf("%s: %s", cv.Skills[i].Category, strings.Join(cv.Skills[i].Items, ", "))
+		},
+		func(i int) error {
+			if i == len(cv.Skills) {
+				var skill models.SkillCategory
+				if err := runSkillForm(&skill); err != nil {
+					return err
+				}
+				cv.Skills = append(cv.Skills, skill)
+				return nil
+			}
+			return runSkillForm(&cv.Skills[i])
+		},
+		func(i int) {
+			cv.Skills = append(cv.Skills[:i], cv.Skills[i+1:]...)
+		},
+	)
+}
+
+func runSkillForm(skill *models.SkillCategory) error {
+	items := strings.Join(skill.Items, ", ")
+	err := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().Title("Category").Placeholder("Programming Languages").
+				Value(&skill.Category).Validate(required("category")),
+			huh.NewInput().Title("Skills").Description("Comma-separated").Value(&items).
+				Validate(func(s string) error {
+					if len(splitList(s)) == 0 {
+						return fmt.Errorf("add at least one skill")
+					}
+					return nil
+				}),
+		),
+	).Run()
+	if err != nil {
+		return err
+	}
+
+	skill.Items = splitList(items)
+	return nil
+}
+
+func editLanguages(cv *models.CV) error {
+	return editList("language", len(cv.Languages),
+		func(i int) string {
+			return fmt.Sprintf("%s (%s)", cv.Languages[i].Name, cv.Languages[i].Level)
+		},
+		func(i int) error {
+			if i == len(cv.Languages) {
+				var lang models.Language
+				if err := runLanguageForm(&lang); err != nil {
+					return err
+				}
+				cv.Languages = append(cv.Languages, lang)
+				return nil
+			}
+			return runLanguageForm(&cv.Languages[i])
+		},
+		func(i int) {
+			cv.Languages = append(cv.Languages[:i], cv.Languages[i+1:]...)
+		},
+	)
+}
+
+func runLanguageForm(lang *models.Language) error {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().Title("Language").Value(&lang.Name).Validate(required("language")),
+			huh.NewInput().Title("Level").Placeholder("Native, Fluent (C1), B2...").
+				Value(&lang.Level).Validate(required("level")),
+		),
+	).Run()
+}
+
+// editList lets the user add, edit and remove the entries of a repeated
+// section until they are done. edit is called with count to add an entry.
+func editList(noun string, count int, label func(i int) string, edit func(i int) error, remove func(i int)) error {
+	for {
+		const done, add = -2, -1
+
+		choice := done
+		options := []huh.Option[int]{huh.NewOption("+ Add "+noun, add)}
+		for i := 0; i < count; i++ {
+			options = append(options, huh.NewOption(label(i), i))
+		}
+		options = append(options, huh.NewOption("Done", done))
+		if count == 0 {
+			choice = add
+		}
+
+		err := huh.NewSelect[int]().
+			Title(fmt.Sprintf("%s (%d)", strings.ToUpper(noun[:1])+noun[1:], count)).
+			Description("Select an entry to edit or remove it").
+			Options(options...).
+			Value(&choice).
+			Run()
+		if err != nil {
+			return err
+		}
+
+		switch choice {
+		case done:
+			return nil
+		case add:
+			if err := edit(count); err != nil {
+				return err
+			}
+			count++
+			continue
+		}
+
+		action := "edit"
+		err = huh.NewSelect[string]().
+			Title(label(choice)).
+			Options(huh.NewOption("Edit", "edit"), huh.NewOption("Remove", "remove"), huh.NewOption("Back", "back")).
+			Value(&action).
+			Run()
+		if err != nil {
+			return err
+		}
+
+		switch action {
+		case "edit":
+			if err := edit(choice); err != nil {
+				return err
+			}
+		case "remove":
+			remove(choice)
+			count--
+		}
+	}
+}
+
+// required returns a validator rejecting empty values
+func required(field string) func(string) error {
+	return func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return fmt.Errorf("%s is required", field)
+		}
+		return nil
+	}
+}
+
+func validateEmail(s string) error {
+	if s == "" {
+		return fmt.Errorf("email is required")
+	}
+	if _, err := mail.ParseAddress(s); err != nil {
+		return fmt.Errorf("invalid email address")
+	}
+	return nil
+}
+
+// validateDate checks the YYYY, YYYY-MM or MM/YYYY format, the latter being
+// the one of the LinkedIn importer
+func validateDate(mandatory bool) func(string) error {
+	return func(s string) error {
+		if s == "" && !mandatory {
+			return nil
+		}
+		if !datePattern.MatchString(models.NormalizeDate(s)) {
+			return fmt.Errorf("use the YYYY-MM, MM/YYYY or YYYY format")
+		}
+		return nil
+	}
+}
+
+func validateEndDate(s string) error {
+	if s != "" && !endDatePattern.MatchString(models.NormalizeDate(s)) {
+		return fmt.Errorf("use the YYYY-MM, MM/YYYY or YYYY format, or 'present'")
+	}
+	return nil
+}
+
+// splitList splits a comma-separated input into trimmed non-empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// splitLines splits a multi-line input into trimmed non-empty lines
+func splitLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Services maps the services secrets can be stored for to the environment
+// variable that takes precedence over the stored secret
+var Services = map[string]string{
+	"linkedin":  "LINKEDIN_COOKIE",
+	"github":    "GITHUB_TOKEN",
+	"gitlab":    "GITLAB_TOKEN",
+	"gitea":     "GITEA_TOKEN",
+	"forgejo":   "FORGEJO_TOKEN",
+	"bitbucket": "BITBUCKET_TOKEN",
+}
+
+// PassphraseEnv is the environment variable holding the passphrase of an
+// encrypted credentials file
+const PassphraseEnv = "RESUMECTL_PASSPHRASE"
+
+// ErrPassphraseRequired is returned when loading an encrypted file without
+// a passphrase
+var ErrPassphraseRequired = errors.New("the credentials file is encrypted: a passphrase is required")
+
+// ErrWrongPassphrase is returned when the passphrase does not decrypt the file
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// scrypt parameters recommended for interactive logins
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// Store holds the secrets of the credentials file
+type Store struct {
+	Path       string
+	Secrets    map[string]string
+	Passphrase string // Secrets are encrypted on Save when set
+}
+
+// file is the on-disk format: plain secrets, or secrets encrypted with
+// AES-256-GCM under a key derived from the passphrase with scrypt
+type file struct {
+	Secrets   map[string]string `json:"secrets,omitempty"`
+	Encrypted *encrypted        `json:"encrypted,omitempty"`
+}
+
+type encrypted struct {
+	KDF   string `json:"kdf"`
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// DefaultPath returns the location of the credentials file:
+// $XDG_CONFIG_HOME/resumectl/credentials.json on Linux
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "resumectl", "credentials.json"), nil
+}
+
+// IsEncrypted reports whether the credentials file at path is encrypted.
+// A missing file is not.
+func IsEncrypted(path string) (bool, error) {
+	f, err := read(path)
+	if err != nil || f == nil {
+		return false, err
+	}
+	return f.Encrypted != nil, nil
+}
+
+// Load reads the credentials file at path. A missing file gives an empty
+// store. passphrase is only used for encrypted files.
+func Load(path, passphrase string) (*Store, error) {
+	s := &Store{Path: path, Secrets: make(map[string]string)}
+
+	f, err := read(path)
+	if err != nil || f == nil {
+		return s, err
+	}
+
+	if f.Encrypted == nil {
+		for service, secret := range f.Secrets {
+			s.Secrets[service] = secret
+		}
+		return s, nil
+	}
+
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+	plain, err := decrypt(f.Encrypted, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(plain, &s.Secrets); err != nil {
+		return nil, fmt.Errorf("invalid credentials file: %w", err)
+	}
+	s.Passphrase = passphrase
+	return s, nil
+}
+
+// read parses the credentials file, refusing files other users can read
+func read(path string) (*file, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("%s is accessible by other users, run: chmod 600 %s", path, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid credentials file %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// Save writes the store with permissions restricted to the user
+func (s *Store) Save() error {
+	var f file
+	if s.Passphrase != "" {
+		plain, err := json.Marshal(s.Secrets)
+		if err != nil {
+			return err
+		}
+		f.Encrypted, err = encrypt(plain, s.Passphrase)
+		if err != nil {
+			return err
+		}
+	} else {
+		f.Secrets = s.Secrets
+	}
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), ".credentials-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// CreateTemp already creates the file with mode 0600
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// Services returns the services with a stored secret, sorted
+func (s *Store) Services() []string {
+	var services []string
+	for service := range s.Secrets {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return services
+}
+
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, 32)
+}
+
+func encrypt(plain []byte, passphrase string) (*encrypted, error) {
+	e := &encrypted{KDF: "scrypt", Salt: make([]byte, 16)}
+	if _, err := rand.Read(e.Salt); err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(passphrase, e.Salt)
+	if err != nil {
+		return nil, err
+	}
+	e.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(e.Nonce); err != nil {
+		return nil, err
+	}
+	e.Data = gcm.Seal(nil, e.Nonce, plain, nil)
+	return e, nil
+}
+
+func decrypt(e *encrypted, passphrase string) ([]byte, error) {
+	if e.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported key derivation '%s'", e.KDF)
+	}
+	gcm, err := newGCM(passphrase, e.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid credentials file: bad nonce")
+	}
+	plain, err := gcm.Open(nil, e.Nonce, e.Data, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plain, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Redact masks a secret for display, keeping only its first characters
+func Redact(secret string) string {
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return secret[:4] + "****"
+}
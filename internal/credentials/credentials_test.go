@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package credentials
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+var secrets = map[string]string{
+	"github":   "ghp_0123456789abcdef",
+	"linkedin": "AQEDAR-li-at-cookie",
+}
+
+// save writes a store in a temporary directory and returns its path
+func save(t *testing.T, passphrase string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "resumectl", "credentials.json")
+	s := &Store{Path: path, Secrets: secrets, Passphrase: passphrase}
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return path
+}
+
+func TestPlain(t *testing.T) {
+	path := save(t, "")
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+	if encrypted, err := IsEncrypted(path); err != nil || encrypted {
+		t.Errorf("IsEncrypted = %v, %v, want false", encrypted, err)
+	}
+
+	// A passphrase given for a plain file is ignored
+	s, err := Load(path, "ignored")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !reflect.DeepEqual(s.Secrets, secrets) {
+		t.Errorf("Secrets = %v, want %v", s.Secrets, secrets)
+	}
+	if s.Passphrase != "" {
+		t.Errorf("Passphrase = %q, want none for a plain file", s.Passphrase)
+	}
+	if want := []string{"github", "linkedin"}; !reflect.DeepEqual(s.Services(), want) {
+		t.Errorf("Services() = %v, want %v", s.Services(), want)
+	}
+}
+
+func TestMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+
+	s, err := Load(path, "")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(s.Secrets) != 0 || s.Path != path {
+		t.Errorf("Load = %+v, want an empty store for %s", s, path)
+	}
+	if encrypted, err := IsEncrypted(path); err != nil || encrypted {
+		t.Errorf("IsEncrypted = %v, %v, want false", encrypted, err)
+	}
+}
+
+func TestEncrypted(t *testing.T) {
+	path := save(t, "correct horse")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range secrets {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("secret %q written in clear:\n%s", secret, data)
+		}
+	}
+	if encrypted, err := IsEncrypted(path); err != nil || !encrypted {
+		t.Errorf("IsEncrypted = %v, %v, want true", encrypted, err)
+	}
+
+	s, err := Load(path, "correct horse")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !reflect.DeepEqual(s.Secrets, secrets) {
+		t.Errorf("Secrets = %v, want %v", s.Secrets, secrets)
+	}
+	// Saving again keeps the file encrypted
+	if s.Passphrase != "correct horse" {
+		t.Errorf("Passphrase = %q, want the one the file was loaded with", s.Passphrase)
+	}
+
+	if _, err := Load(path, "wrong horse"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Load with a wrong passphrase: err = %v, want ErrWrongPassphrase", err)
+	}
+	if _, err := Load(path, ""); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("Load without a passphrase: err = %v, want ErrPassphraseRequired", err)
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	plain := []byte(`{"github":"ghp_secret"}`)
+
+	first, err := encrypt(plain, "passphrase")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	second, err := encrypt(plain, "passphrase")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if reflect.DeepEqual(first.Salt, second.Salt) || reflect.DeepEqual(first.Nonce, second.Nonce) {
+		t.Error("salt and nonce must be random")
+	}
+
+	got, err := decrypt(first, "passphrase")
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	if string(got) != string(plain) {
+		t.Errorf("decrypt = %q, want %q", got, plain)
+	}
+
+	// Tampered data does not decrypt
+	first.Data[0] ^= 1
+	if _, err := decrypt(first, "passphrase"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("decrypt tampered data: err = %v, want ErrWrongPassphrase", err)
+	}
+
+	second.KDF = "pbkdf2"
+	if _, err := decrypt(second, "passphrase"); err == nil {
+		t.Error("decrypt with an unknown KDF: want an error")
+	}
+}
+
+func TestPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not enforced on Windows")
+	}
+
+	for _, mode := range []os.FileMode{0640, 0604, 0644} {
+		path := save(t, "")
+		if err := os.Chmod(path, mode); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := Load(path, ""); err == nil || !strings.Contains(err.Error(), "chmod 600") {
+			t.Errorf("Load of a %v file: err = %v, want a refusal", mode, err)
+		}
+		if _, err := IsEncrypted(path); err == nil {
+			t.Errorf("IsEncrypted of a %v file: want a refusal", mode)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	for secret, want := range map[string]string{
+		"":                     "****",
+		"short":                "****",
+		"ghp_0123456789abcdef": "ghp_****",
+	} {
+		if got := Redact(secret); got != want {
+			t.Errorf("Redact(%q) = %q, want %q", secret, got, want)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package docx writes a CV as a Word document (Office Open XML). The
+// document only uses built-in styles so that it stays easy to edit.
+package docx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"resumectl/internal/models"
+)
+
+const contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+</Types>`
+
+const packageRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+
+const documentRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+// styles defines the paragraph styles used by the document. %s is the
+// primary color (RRGGBB) used for the name and the section headings.
+const styles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults>
+<w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:cs="Calibri"/><w:sz w:val="21"/></w:rPr></w:rPrDefault>
+<w:pPrDefault><w:pPr><w:spacing w:after="60"/></w:pPr></w:pPrDefault>
+</w:docDefaults>
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/></w:style>
+<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/>
+<w:pPr><w:spacing w:after="0"/></w:pPr><w:rPr><w:b/><w:color w:val="%[1]s"/><w:sz w:val="48"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Subtitle"><w:name w:val="Subtitle"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/>
+<w:rPr><w:sz w:val="28"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/>
+<w:pPr><w:keepNext/><w:pBdr><w:bottom w:val="single" w:sz="6" w:space="1" w:color="%[1]s"/></w:pBdr><w:spacing w:before="240" w:after="80"/><w:outlineLvl w:val="0"/></w:pPr>
+<w:rPr><w:b/><w:caps/><w:color w:val="%[1]s"/><w:sz w:val="24"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/>
+<w:pPr><w:keepNext/><w:spacing w:before="120" w:after="0"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:sz w:val="22"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Meta"><w:name w:val="Meta"/><w:basedOn w:val="Normal"/>
+<w:rPr><w:i/><w:color w:val="6B7280"/><w:sz w:val="19"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Bullet"><w:name w:val="Bullet"/><w:basedOn w:val="Normal"/>
+<w:pPr><w:spacing w:after="20"/><w:ind w:left="360" w:hanging="220"/></w:pPr></w:style>
+</w:styles>`
+
+// DefaultColor is the color of the name and headings when none is given
+const DefaultColor = "#2563eb"
+
+// document accumulates the paragraphs of word/document.xml
+type document struct {
+	body strings.Builder
+}
+
+// paragraph adds a paragraph with a style and runs of text. Runs starting
+// with "**" are bold.
+func (d *document) paragraph(style string, runs ...string) {
+	d.body.WriteString("<w:p>")
+	if style != "" {
+		fmt.Fprintf(&d.body, `<w:pPr><w:pStyle w:val="%s"/></w:pPr>`, style)
+	}
+	for _, run := range runs {
+		if run == "" {
+			continue
+		}
+		d.body.WriteString("<w:r>")
+		if text, bold := strings.CutPrefix(run, "**"); bold {
+			d.body.WriteString("<w:rPr><w:b/></w:rPr>")
+			run = text
+		}
+		d.body.WriteString(`<w:t xml:space="preserve">`)
+		xml.EscapeText(&d.body, []byte(run))
+		d.body.WriteString("</w:t></w:r>")
+	}
+	d.body.WriteString("</w:p>")
+}
+
+func (d *document) bullet(text string) {
+	d.paragraph("Bullet", "•  "+text)
+}
+
+// Write saves cv as a .docx file at path, using color (#RRGGBB, or
+// DefaultColor when empty) for the name and the headings
+func Write(cv *models.CV, color, path string) error {
+	data := render(cv)
+
+	if color == "" {
+		color = DefaultColor
+	}
+	color = strings.ToUpper(strings.TrimPrefix(color, "#"))
+	if len(color) == 3 {
+		color = string([]byte{color[0], color[0], color[1], color[1], color[2], color[2]})
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(f)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", packageRels},
+		{"word/_rels/document.xml.rels", documentRels},
+		{"word/styles.xml", fmt.Sprintf(styles, color)},
+		{"word/document.xml", data},
+	}
+	for _, part := range parts {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// render returns word/document.xml for cv
+func render(cv *models.CV) string {
+	var d document
+	p := cv.Personal
+
+	d.paragraph("Title", p.FullName())
+	d.paragraph("Subtitle", p.Title)
+
+	var contact []string
+	for _, item := range []string{p.Email, p.Phone, p.Location, p.LinkedIn, p.GitHub, p.Website} {
+		if item != "" {
+			contact = append(contact, item)
+		}
+	}
+	if len(contact) > 0 {
+		d.paragraph("Meta", strings.Join(contact, " · "))
+	}
+
+	if cv.Summary != "" {
+		d.paragraph("Heading1", "Summary")
+		d.paragraph("", cv.Summary)
+	}
+
+	if len(cv.Experience) > 0 {
+		d.paragraph("Heading1", "Professional Experience")
+		for _, exp := range cv.Experience {
+			d.paragraph("Heading2", exp.Position, prefixed(" - ", exp.Company))
+			d.paragraph("Meta", joinNonEmpty(" | ", period(exp.StartDate, exp.EndDate), exp.Location))
+			if exp.Description != "" {
+				d.paragraph("", exp.Description)
+			}
+			for _, highlight := range exp.Highlights {
+				d.bullet(highlight)
+			}
+		}
+	}
+
+	if len(cv.Education) > 0 {
+		d.paragraph("Heading1", "Education")
+		for _, edu := range cv.Education {
+			d.paragraph("Heading2", joinNonEmpty(" - ", edu.Degree, edu.Field))
+			d.paragraph("", edu.Institution)
+			d.paragraph("Meta", joinNonEmpty(" | ", period(edu.StartDate, edu.EndDate), edu.Location))
+			if edu.Description != "" {
+				d.paragraph("", edu.Description)
+			}
+		}
+	}
+
+	if len(cv.Projects) > 0 {
+		d.paragraph("Heading1", "Projects")
+		for _, proj := range cv.Projects {
+			d.paragraph("Heading2", proj.Name)
+			if proj.Description != "" {
+				d.paragraph("", proj.Description)
+			}
+			d.paragraph("Meta", joinNonEmpty(" | ", strings.Join(proj.Technologies, ", "), proj.URL))
+		}
+	}
+
+	if len(cv.Skills) > 0 {
+		d.paragraph("Heading1", "Skills")
+		for _, skill := range cv.Skills {
+			d.paragraph("", "**"+skill.Category+": ", strings.Join(skill.Items, ", "))
+		}
+	}
+
+	if len(cv.Languages) > 0 {
+		d.paragraph("Heading1", "Languages")
+		for _, lang := range cv.Languages {
+			d.paragraph("", "**"+lang.Name, prefixed(" - ", lang.Level))
+		}
+	}
+
+	if len(cv.Certifications) > 0 {
+		d.paragraph("Heading1", "Certifications")
+		for _, cert := range cv.Certifications {
+			d.paragraph("", "**"+cert.Name, prefixed(" - ", joinNonEmpty(", ", cert.Issuer, cert.Date)))
+		}
+	}
+
+	if len(cv.Interests) > 0 {
+		d.paragraph("Heading1", "Interests")
+		d.paragraph("", strings.Join(cv.Interests, ", "))
+	}
+
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		d.body.String() +
+		`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1000" w:right="1000" w:bottom="1000" w:left="1000" w:header="0" w:footer="0" w:gutter="0"/></w:sectPr></w:body></w:document>`
+}
+
+// period formats a start and end date as shown in the HTML output
+func period(start, end string) string {
+	if start == "" {
+		return models.FormatDate(end)
+	}
+	if end == "" {
+		return start
+	}
+	return start + " - " + models.FormatDate(end)
+}
+
+// joinNonEmpty joins the non-empty parts with sep
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+// prefixed returns s after prefix, or nothing when s is empty
+func prefixed(prefix, s string) string {
+	if s == "" {
+		return ""
+	}
+	return prefix + s
+}
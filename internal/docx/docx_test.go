@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package docx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"resumectl/internal/models"
+)
+
+// readParts opens a .docx file and returns its parts by name, in order
+func readParts(t *testing.T, path string) ([]string, map[string]string) {
+	t.Helper()
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("opening %s: %v", path, err)
+	}
+	defer zr.Close()
+
+	var names []string
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("opening part %s: %v", f.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("reading part %s: %v", f.Name, err)
+		}
+		names = append(names, f.Name)
+		parts[f.Name] = string(data)
+	}
+	return names, parts
+}
+
+// paragraphs decodes word/document.xml into the text of each paragraph,
+// with bold runs wrapped in **
+func paragraphs(t *testing.T, document string) []string {
+	t.Helper()
+
+	var paras []string
+	var text strings.Builder
+	bold := false
+	dec := xml.NewDecoder(strings.NewReader(document))
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("word/document.xml is not well-formed: %v", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "p":
+				text.Reset()
+			case "r":
+				bold = false
+			case "b":
+				bold = true
+			}
+		case xml.CharData:
+			if bold {
+				text.WriteString("**" + string(tok) + "**")
+			} else {
+				text.Write(tok)
+			}
+		case xml.EndElement:
+			if tok.Name.Local == "p" {
+				paras = append(paras, text.String())
+			}
+		}
+	}
+	return paras
+}
+
+func TestWrite(t *testing.T) {
+	cv := &models.CV{
+		Personal: models.Personal{
+			FirstName: "Jane",
+			LastName:  "Doe",
+			Title:     "Platform Engineer",
+			Email:     "jane@example.com",
+			Location:  "Lyon",
+		},
+		Summary: "Builds <reliable> platforms & tools.",
+		Experience: []models.Experience{{
+			Company:    "Acme",
+			Position:   "SRE",
+			StartDate:  "2020-01",
+			EndDate:    "present",
+			Highlights: []string{"Cut costs by 30%"},
+		}},
+		Skills: []models.SkillCategory{{Category: "Cloud", Items: []string{"AWS", "GCP"}}},
+	}
+
+	path := filepath.Join(t.TempDir(), "cv.docx")
+	if err := Write(cv, "#0f766e", path); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	names, parts := readParts(t, path)
+	want := []string{"[Content_Types].xml", "_rels/.rels", "word/_rels/document.xml.rels", "word/styles.xml", "word/document.xml"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("parts = %v, want %v", names, want)
+	}
+	for name, content := range parts {
+		if err := xml.Unmarshal([]byte(content), new(struct{})); err != nil {
+			t.Errorf("%s is not well-formed: %v", name, err)
+		}
+	}
+	for _, part := range []string{"/word/document.xml", "/word/styles.xml"} {
+		if !strings.Contains(parts["[Content_Types].xml"], `PartName="`+part+`"`) {
+			t.Errorf("[Content_Types].xml does not declare %s", part)
+		}
+	}
+	if !strings.Contains(parts["word/styles.xml"], `w:color w:val="0F766E"`) {
+		t.Errorf("styles.xml does not use the primary color:\n%s", parts["word/styles.xml"])
+	}
+
+	got := paragraphs(t, parts["word/document.xml"])
+	wantParas := []string{
+		"Jane Doe",
+		"Platform Engineer",
+		"jane@example.com · Lyon",
+		"Summary",
+		"Builds <reliable> platforms & tools.",
+		"Professional Experience",
+		"SRE - Acme",
+		"2020-01 - " + models.FormatDate("present"),
+		"•\u00a0 Cut costs by 30%",
+		"Skills",
+		"**Cloud: **AWS, GCP",
+	}
+	if !reflect.DeepEqual(got, wantParas) {
+		t.Errorf("paragraphs:\n%q\nwant:\n%q", got, wantParas)
+	}
+}
+
+func TestWriteColor(t *testing.T) {
+	for color, want := range map[string]string{
+		"":     strings.ToUpper(strings.TrimPrefix(DefaultColor, "#")),
+		"#abc": "AABBCC",
+	} {
+		path := filepath.Join(t.TempDir(), "cv.docx")
+		if err := Write(&models.CV{}, color, path); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		_, parts := readParts(t, path)
+		if !strings.Contains(parts["word/styles.xml"], `w:color w:val="`+want+`"`) {
+			t.Errorf("color %q: styles.xml does not use %s", color, want)
+		}
+	}
+}
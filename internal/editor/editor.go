@@ -0,0 +1,412 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package editor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// Options configures the editor
+type Options struct {
+	Title  string                                          // Shown in the header, usually the file name
+	Render func(doc *yaml.Node, width int) (string, error) // Renders the preview pane
+	Save   func(doc *yaml.Node) error                      // Writes the document back
+}
+
+// Run opens the full-screen editor on a YAML document whose root is a
+// mapping. Edits are made on the nodes in place, so comments are kept.
+func Run(doc *yaml.Node, opts Options) error {
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("the document is not a mapping")
+	}
+
+	_, err := tea.NewProgram(newModel(doc, root, opts), tea.WithAltScreen()).Run()
+	return err
+}
+
+type mode int
+
+const (
+	browsing    mode = iota
+	editingLine      // Single-line value in a text input
+	editingText      // Multi-line value in a text area
+)
+
+const helpText = "↑/↓ move • ←/→ fold • enter edit • K/J reorder • pgup/pgdn scroll preview • s save • q quit"
+
+var (
+	headerStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	helpStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	statusStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	paneStyle     = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderLeft(true).PaddingLeft(1)
+)
+
+type model struct {
+	doc      *yaml.Node
+	root     *yaml.Node
+	opts     Options
+	expanded map[*yaml.Node]bool
+	rows     []row
+	cursor   int
+	offset   int
+
+	mode    mode
+	input   textinput.Model
+	area    textarea.Model
+	preview viewport.Model
+
+	width, height int
+	modified      bool
+	confirmQuit   bool
+	status        string
+}
+
+func newModel(doc, root *yaml.Node, opts Options) *model {
+	m := &model{
+		doc:      doc,
+		root:     root,
+		opts:     opts,
+		expanded: make(map[*yaml.Node]bool),
+		input:    textinput.New(),
+		area:     textarea.New(),
+		preview:  viewport.New(0, 0),
+	}
+	m.area.ShowLineNumbers = false
+	m.refreshRows()
+	return m
+}
+
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.preview.Width = m.previewWidth()
+		m.preview.Height = m.paneHeight()
+		m.area.SetWidth(m.treeWidth() - 2)
+		m.area.SetHeight(m.paneHeight() - 2)
+		m.refreshPreview()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.mode {
+		case editingLine, editingText:
+			return m.updateEditing(msg)
+		}
+		return m.updateBrowsing(msg)
+	}
+	return m, nil
+}
+
+func (m *model) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key != "q" && key != "ctrl+c" {
+		m.confirmQuit = false
+	}
+	m.status = ""
+
+	switch key {
+	case "q", "ctrl+c":
+		if m.modified && !m.confirmQuit {
+			m.confirmQuit = true
+			m.status = "Unsaved changes: press q again to quit without saving, s to save"
+			return m, nil
+		}
+		return m, tea.Quit
+
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "home", "g":
+		m.moveCursor(-len(m.rows))
+	case "end", "G":
+		m.moveCursor(len(m.rows))
+
+	case "left", "h":
+		r := m.current()
+		if r.collection() && m.expanded[r.node] {
+			m.expanded[r.node] = false
+			m.refreshRows()
+		} else {
+			m.selectNode(r.parent)
+		}
+	case "right", "l":
+		if r := m.current(); r.collection() {
+			m.expanded[r.node] = true
+			m.refreshRows()
+		}
+
+	case "enter", " ", "e":
+		r := m.current()
+		if r.collection() {
+			m.expanded[r.node] = !m.expanded[r.node]
+			m.refreshRows()
+		} else if r.node.Kind == yaml.ScalarNode {
+			return m, m.startEditing(r)
+		}
+
+	case "K", "shift+up", "alt+up":
+		m.moveItem(-1)
+	case "J", "shift+down", "alt+down":
+		m.moveItem(1)
+
+	case "pgup":
+		m.preview.HalfPageUp()
+	case "pgdown":
+		m.preview.HalfPageDown()
+
+	case "s", "ctrl+s":
+		m.save()
+	}
+	return m, nil
+}
+
+func (m *model) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = browsing
+		m.status = "Edit cancelled"
+		return m, nil
+	case "ctrl+s":
+		m.commit()
+		return m, nil
+	case "enter":
+		if m.mode == editingLine {
+			m.commit()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.mode == editingLine {
+		m.input, cmd = m.input.Update(msg)
+	} else {
+		m.area, cmd = m.area.Update(msg)
+	}
+	return m, cmd
+}
+
+// startEditing opens the input matching the value: a text area for
+// multi-line values and long texts, a text input otherwise
+func (m *model) startEditing(r row) tea.Cmd {
+	if strings.Contains(r.node.Value, "\n") || r.key == "summary" || r.key == "description" {
+		m.mode = editingText
+		m.area.SetValue(r.node.Value)
+		return m.area.Focus()
+	}
+
+	m.mode = editingLine
+	m.input.SetValue(r.node.Value)
+	m.input.CursorEnd()
+	m.input.Width = m.treeWidth() - lipgloss.Width(m.rowPrefix(r)) - 3
+	return m.input.Focus()
+}
+
+func (m *model) commit() {
+	r := m.current()
+	value := m.input.Value()
+	if m.mode == editingText {
+		value = strings.TrimRight(m.area.Value(), "\n")
+	}
+	m.mode = browsing
+
+	if value == r.node.Value {
+		return
+	}
+	setScalar(r.node, value)
+	m.changed()
+}
+
+func (m *model) moveItem(delta int) {
+	r := m.current()
+	if !r.movable() {
+		m.status = "Only list entries can be reordered"
+		return
+	}
+	if move(r.parent, r.index, delta) == r.index {
+		return
+	}
+	m.changed()
+	m.selectNode(r.node)
+}
+
+func (m *model) save() {
+	if err := m.opts.Save(m.doc); err != nil {
+		m.status = "Error saving: " + err.Error()
+		return
+	}
+	m.modified = false
+	m.status = "Saved"
+}
+
+// changed refreshes the tree and the preview after an edit
+func (m *model) changed() {
+	m.modified = true
+	m.refreshRows()
+	m.refreshPreview()
+}
+
+func (m *model) refreshRows() {
+	m.rows = flatten(m.root, m.expanded)
+	m.moveCursor(0)
+}
+
+func (m *model) refreshPreview() {
+	if m.previewWidth() <= 0 {
+		return
+	}
+	content, err := m.opts.Render(m.doc, m.previewWidth())
+	if err != nil {
+		content = "Cannot render the preview:\n\n" + err.Error()
+	}
+	m.preview.SetContent(content)
+}
+
+func (m *model) current() row {
+	if len(m.rows) == 0 {
+		return row{node: m.root, index: -1}
+	}
+	return m.rows[m.cursor]
+}
+
+func (m *model) moveCursor(delta int) {
+	m.cursor += delta
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+
+	height := m.paneHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if height > 0 && m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+}
+
+// selectNode moves the cursor to the row of a node
+func (m *model) selectNode(n *yaml.Node) {
+	for i, r := range m.rows {
+		if r.node == n {
+			m.moveCursor(i - m.cursor)
+			return
+		}
+	}
+}
+
+func (m *model) treeWidth() int {
+	w := m.width * 2 / 5
+	if w < 30 {
+		w = min(30, m.width)
+	}
+	return w
+}
+
+func (m *model) previewWidth() int {
+	return m.width - m.treeWidth() - 2
+}
+
+func (m *model) paneHeight() int {
+	return m.height - 2
+}
+
+func (m *model) rowPrefix(r row) string {
+	return strings.Repeat("  ", r.depth)
+}
+
+func (m *model) View() string {
+	if m.width == 0 {
+		return ""
+	}
+
+	title := "resumectl edit · " + m.opts.Title
+	if m.modified {
+		title += " [modified]"
+	}
+	header := headerStyle.Render(title)
+
+	footer := helpStyle.Render(helpText)
+	switch {
+	case m.status != "":
+		footer = statusStyle.Render(m.status)
+	case m.mode == editingLine:
+		footer = helpStyle.Render("enter save • esc cancel")
+	case m.mode == editingText:
+		footer = helpStyle.Render("ctrl+s save • esc cancel")
+	}
+
+	tree := lipgloss.NewStyle().Width(m.treeWidth()).Height(m.paneHeight()).MaxHeight(m.paneHeight()).
+		Render(m.treeView())
+	preview := paneStyle.Height(m.paneHeight()).MaxHeight(m.paneHeight()).Render(m.preview.View())
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.JoinHorizontal(lipgloss.Top, tree, preview),
+		footer,
+	)
+}
+
+func (m *model) treeView() string {
+	if m.mode == editingText {
+		r := m.current()
+		return headerStyle.Render(r.key) + "\n" + m.area.View()
+	}
+
+	width := m.treeWidth()
+	var lines []string
+	for i := m.offset; i < len(m.rows) && i < m.offset+m.paneHeight(); i++ {
+		r := m.rows[i]
+		prefix := m.rowPrefix(r)
+
+		if i == m.cursor && m.mode == editingLine {
+			label := "  "
+			if r.key != "" {
+				label += r.key + ": "
+			} else {
+				label = "- "
+			}
+			lines = append(lines, prefix+label+m.input.View())
+			continue
+		}
+
+		line := lipgloss.NewStyle().MaxWidth(width).Render(prefix + r.label(m.expanded[r.node]))
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
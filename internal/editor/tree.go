@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package editor
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// row is a line of the section tree: a value of the YAML document with
+// the mapping key or sequence position it hangs from
+type row struct {
+	node   *yaml.Node // Value node
+	key    string     // Mapping key, empty for sequence items
+	parent *yaml.Node // Enclosing mapping or sequence
+	index  int        // Position in the parent sequence, -1 for mapping values
+	depth  int
+}
+
+// collection reports whether the row can be expanded
+func (r row) collection() bool {
+	return r.node.Kind == yaml.MappingNode || r.node.Kind == yaml.SequenceNode
+}
+
+// movable reports whether the row is an item that can be reordered
+func (r row) movable() bool {
+	return r.parent != nil && r.parent.Kind == yaml.SequenceNode
+}
+
+// flatten lists the rows of the expanded parts of a mapping
+func flatten(root *yaml.Node, expanded map[*yaml.Node]bool) []row {
+	var rows []row
+	var walk func(parent *yaml.Node, depth int)
+	walk = func(parent *yaml.Node, depth int) {
+		switch parent.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(parent.Content); i += 2 {
+				r := row{node: parent.Content[i+1], key: parent.Content[i].Value, parent: parent, index: -1, depth: depth}
+				rows = append(rows, r)
+				if r.collection() && expanded[r.node] {
+					walk(r.node, depth+1)
+				}
+			}
+		case yaml.SequenceNode:
+			for i, item := range parent.Content {
+				r := row{node: item, parent: parent, index: i, depth: depth}
+				rows = append(rows, r)
+				if r.collection() && expanded[r.node] {
+					walk(r.node, depth+1)
+				}
+			}
+		}
+	}
+	walk(root, 0)
+	return rows
+}
+
+// label returns the text of a row without indentation
+func (r row) label(expanded bool) string {
+	marker := "  "
+	if r.collection() {
+		marker = "▸ "
+		if expanded {
+			marker = "▾ "
+		}
+	}
+
+	switch {
+	case r.key != "" && r.collection():
+		return fmt.Sprintf("%s%s (%d)", marker, r.key, count(r.node))
+	case r.key != "":
+		return fmt.Sprintf("%s%s: %s", marker, r.key, scalarText(r.node))
+	case r.collection():
+		return marker + summary(r.node)
+	}
+	return "- " + scalarText(r.node)
+}
+
+// count returns the number of entries of a collection
+func count(n *yaml.Node) int {
+	if n.Kind == yaml.MappingNode {
+		return len(n.Content) / 2
+	}
+	return len(n.Content)
+}
+
+// summaryFormats describe list entries by section, the first format whose
+// fields are all set is used
+var summaryFormats = []struct {
+	fields []string
+	sep    string
+}{
+	{[]string{"position", "company"}, " @ "},
+	{[]string{"degree", "institution"}, ", "},
+	{[]string{"name", "level"}, " · "},
+	{[]string{"name", "issuer"}, " · "},
+	{[]string{"category"}, ""},
+	{[]string{"name"}, ""},
+}
+
+// summary describes a sequence item in one line ("Developer @ Acme")
+func summary(n *yaml.Node) string {
+	if n.Kind == yaml.SequenceNode {
+		return fmt.Sprintf("[%d items]", len(n.Content))
+	}
+
+	for _, format := range summaryFormats {
+		var parts []string
+		for _, field := range format.fields {
+			if v := fieldValue(n, field); v != "" {
+				parts = append(parts, v)
+			}
+		}
+		if len(parts) == len(format.fields) {
+			return strings.Join(parts, format.sep)
+		}
+	}
+
+	for i := 1; i < len(n.Content); i += 2 {
+		if n.Content[i].Kind == yaml.ScalarNode && n.Content[i].Value != "" {
+			return n.Content[i].Value
+		}
+	}
+	return "{}"
+}
+
+func fieldValue(n *yaml.Node, key string) string {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key && n.Content[i+1].Kind == yaml.ScalarNode {
+			return n.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// scalarText shows a scalar on one line
+func scalarText(n *yaml.Node) string {
+	if n.Kind != yaml.ScalarNode {
+		return ""
+	}
+	text := strings.ReplaceAll(strings.TrimSpace(n.Value), "\n", " ⏎ ")
+	if text == "" {
+		return `""`
+	}
+	return text
+}
+
+// setScalar replaces the value of a scalar. The tag is kept when the new
+// value still resolves to it (so that booleans stay booleans), otherwise
+// the value becomes a string.
+func setScalar(n *yaml.Node, value string) {
+	tag := "!!str"
+	if n.Tag != "!!str" && n.Tag != "!!null" {
+		var parsed yaml.Node
+		if err := yaml.Unmarshal([]byte(value), &parsed); err == nil &&
+			len(parsed.Content) == 1 && parsed.Content[0].Tag == n.Tag {
+			tag = n.Tag
+		}
+	}
+
+	n.Value = value
+	n.Tag = tag
+	if strings.Contains(value, "\n") {
+		n.Style = yaml.LiteralStyle
+	} else if n.Style == yaml.LiteralStyle || n.Style == yaml.FoldedStyle {
+		n.Style = 0
+	}
+}
+
+// move swaps a sequence item with its neighbour and returns its new position
+func move(seq *yaml.Node, index, delta int) int {
+	target := index + delta
+	if target < 0 || target >= len(seq.Content) {
+		return index
+	}
+	seq.Content[index], seq.Content[target] = seq.Content[target], seq.Content[index]
+	return target
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package forge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DebugMode enables debug output
+var DebugMode bool
+
+// HTTPClient is the client used by the forge API clients
+var HTTPClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+// Ranking strategies for FetchProjects
+const (
+	RankStars   = "stars"   // Most starred first (default)
+	RankRecent  = "recent"  // Most recently pushed first
+	RankCommits = "commits" // Most commits by the user first
+	RankPinned  = "pinned"  // Repositories pinned on the profile
+)
+
+// RankStrategies lists the ranking strategies known to resumectl. Sources
+// may support only some of them.
+var RankStrategies = []string{RankStars, RankRecent, RankCommits, RankPinned}
+
+// minLanguageShare is the share of a repository's code a language must reach
+// to be listed in the project technologies
+const minLanguageShare = 0.05
+
+// Project represents a project to add to CV
+type Project struct {
+	ID           string // Identifier used to query the forge API for details
+	Name         string
+	Description  string
+	URL          string
+	Technologies []string
+	Stars        int
+	Forks        int
+	Languages    map[string]int // Bytes of code (or relative share) per language
+	Topics       []string
+	PushedAt     time.Time
+	Commits      int // Commits by the user (only computed with RankCommits)
+}
+
+// Options controls which repositories a source selects
+type Options struct {
+	Count       int      // Number of projects to return (default: 5)
+	Rank        string   // One of RankStrategies (default: RankStars)
+	Include     []string // Keep only repositories matching one of these patterns
+	Exclude     []string // Drop repositories matching one of these patterns
+	IncludeOrgs bool     // Also consider organization/group repositories the user contributed to
+}
+
+// ProjectSource is a code hosting service projects can be imported from
+type ProjectSource interface {
+	// Name returns a human-readable name of the service (e.g. "GitLab")
+	Name() string
+	// FetchProjects selects the best projects of a user according to opts
+	FetchProjects(username string, opts Options) ([]Project, error)
+}
+
+// Repo is the forge-independent view of a repository used for selection
+type Repo struct {
+	ID          string // Identifier used to query the forge API for details
+	Name        string
+	Description string
+	URL         string
+	Language    string
+	Topics      []string
+	Stars       int
+	Forks       int
+	Fork        bool
+	Archived    bool
+	PushedAt    time.Time
+}
+
+// Defaults fills in the default count and ranking
+func (o *Options) Defaults() {
+	if o.Count <= 0 {
+		o.Count = 5
+	}
+	if o.Rank == "" {
+		o.Rank = RankStars
+	}
+}
+
+// Keep reports whether a repository passes the fork/archive and pattern filters.
+// Patterns are either name globs ("terraform-*") or topics prefixed with
+// "topic:" ("topic:kubernetes").
+func (o Options) Keep(name string, topics []string, fork, archived bool) bool {
+	if fork || archived {
+		return false
+	}
+	if len(o.Include) > 0 && !MatchesAny(name, topics, o.Include) {
+		return false
+	}
+	return !MatchesAny(name, topics, o.Exclude)
+}
+
+// Select filters repositories, orders them by stars or recency and returns
+// the top opts.Count as projects. Other rankings must be handled by the source.
+func Select(repos []Repo, opts Options) ([]Project, error) {
+	opts.Defaults()
+
+	var candidates []Repo
+	for _, repo := range repos {
+		if opts.Keep(repo.Name, repo.Topics, repo.Fork, repo.Archived) {
+			candidates = append(candidates, repo)
+		}
+	}
+
+	switch opts.Rank {
+	case RankStars:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Stars > candidates[j].Stars
+		})
+	case RankRecent:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].PushedAt.After(candidates[j].PushedAt)
+		})
+	default:
+		return nil, fmt.Errorf("unsupported ranking '%s'", opts.Rank)
+	}
+
+	if len(candidates) > opts.Count {
+		candidates = candidates[:opts.Count]
+	}
+
+	projects := make([]Project, 0, len(candidates))
+	for _, repo := range candidates {
+		proj := Project{
+			ID:          repo.ID,
+			Name:        repo.Name,
+			Description: repo.Description,
+			URL:         repo.URL,
+			Stars:       repo.Stars,
+			Forks:       repo.Forks,
+			Topics:      repo.Topics,
+			PushedAt:    repo.PushedAt,
+		}
+		if repo.Language != "" {
+			proj.Technologies = append(proj.Technologies, repo.Language)
+		}
+		for _, topic := range repo.Topics {
+			if !ContainsIgnoreCase(proj.Technologies, topic) {
+				proj.Technologies = append(proj.Technologies, topic)
+			}
+		}
+		projects = append(projects, proj)
+	}
+
+	return projects, nil
+}
+
+// SetLanguages records the language breakdown of a project and lists every
+// significant language first in its technologies, largest first
+func SetLanguages(proj *Project, languages map[string]int) {
+	if len(languages) == 0 {
+		return
+	}
+	proj.Languages = languages
+
+	total := 0
+	names := make([]string, 0, len(languages))
+	for name, size := range languages {
+		total += size
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if languages[names[i]] != languages[names[j]] {
+			return languages[names[i]] > languages[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	var technologies []string
+	for _, name := range names {
+		if total > 0 && float64(languages[name])/float64(total) >= minLanguageShare {
+			technologies = append(technologies, name)
+		}
+	}
+	for _, tech := range proj.Technologies {
+		if !ContainsIgnoreCase(technologies, tech) && languages[tech] == 0 {
+			technologies = append(technologies, tech)
+		}
+	}
+	proj.Technologies = technologies
+}
+
+// MatchesAny reports whether a repository matches one of the patterns
+func MatchesAny(name string, topics []string, patterns []string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if topic, ok := strings.CutPrefix(pattern, "topic:"); ok {
+			if ContainsIgnoreCase(topics, topic) {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsIgnoreCase checks if a slice contains a string (case insensitive)
+func ContainsIgnoreCase(slice []string, str string) bool {
+	for _, s := range slice {
+		if strings.EqualFold(s, str) {
+			return true
+		}
+	}
+	return false
+}
+
+// Client is a minimal JSON API client shared by the forge implementations
+type Client struct {
+	BaseURL string
+	// Authorize adds credentials to a request (may be nil)
+	Authorize func(req *http.Request)
+}
+
+// Get fetches url (absolute, or relative to BaseURL) and decodes the JSON
+// response into v. It returns the response headers for pagination.
+func (c *Client) Get(url string, v interface{}) (http.Header, error) {
+	if !strings.Contains(url, "://") {
+		url = strings.TrimSuffix(c.BaseURL, "/") + url
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "resumectl/1.0")
+	if c.Authorize != nil {
+		c.Authorize(req)
+	}
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if DebugMode {
+		fmt.Printf("[DEBUG] GET %s: %d\n", url, resp.StatusCode)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("access denied (status %d): check your token", resp.StatusCode)
+	case http.StatusTooManyRequests:
+		msg := "API rate limit exceeded"
+		if retry := resp.Header.Get("Retry-After"); retry != "" {
+			msg += ", retry after " + retry + "s"
+		}
+		return nil, fmt.Errorf("%s; use a token for a higher limit", msg)
+	default:
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+	return resp.Header, nil
+}
+
+// ErrNotFound is returned by Client.Get for 404 responses
+var ErrNotFound = errors.New("not found")
@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package forge
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientGet(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI()+" "+r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/api/items":
+			w.Header().Set("X-Next-Page", "2")
+			io.WriteString(w, `{"name": "item"}`)
+		case "/api/private":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/api/limited":
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/api/broken":
+			io.WriteString(w, "<html>")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	// The trailing slash of the base URL is not doubled
+	c := &Client{
+		BaseURL:   srv.URL + "/api/",
+		Authorize: func(req *http.Request) { req.Header.Set("Authorization", "token secret") },
+	}
+
+	var item struct {
+		Name string `json:"name"`
+	}
+	header, err := c.Get("/items?page=1", &item)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if item.Name != "item" || header.Get("X-Next-Page") != "2" {
+		t.Errorf("Get = %+v with headers %v", item, header)
+	}
+
+	// Absolute URLs (e.g. Bitbucket "next" links) are used as is
+	if _, err := c.Get(srv.URL+"/api/items?page=2", &item); err != nil {
+		t.Fatalf("Get absolute URL: %v", err)
+	}
+
+	if _, err := c.Get("/missing", &item); !errors.Is(err, ErrNotFound) {
+		t.Errorf("404: err = %v, want ErrNotFound", err)
+	}
+	for endpoint, want := range map[string]string{
+		"/private": "access denied (status 401): check your token",
+		"/limited": "API rate limit exceeded, retry after 30s; use a token for a higher limit",
+		"/broken":  "error decoding response",
+	} {
+		if _, err := c.Get(endpoint, &item); err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", endpoint, err, want)
+		}
+	}
+
+	for _, request := range requests[:2] {
+		if !strings.HasPrefix(request, "/api/items?page=") || !strings.HasSuffix(request, " token secret") {
+			t.Errorf("request %q, want /api/items with the token", request)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	repos := []Repo{
+		{Name: "tool", Stars: 50, PushedAt: day(1), Language: "Go", Topics: []string{"cli", "go"}},
+		{Name: "site", Stars: 5, PushedAt: day(9)},
+		{Name: "infra", Stars: 20, PushedAt: day(6), Topics: []string{"terraform"}},
+		{Name: "fork", Stars: 100, PushedAt: day(8), Fork: true},
+		{Name: "old", Stars: 80, Archived: true},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+		err  string
+	}{
+		{name: "stars", opts: Options{Count: 2}, want: []string{"tool", "infra"}},
+		{name: "recent", opts: Options{Rank: RankRecent}, want: []string{"site", "infra", "tool"}},
+		{name: "include", opts: Options{Include: []string{"topic:TERRAFORM", "s*"}}, want: []string{"infra", "site"}},
+		{name: "exclude", opts: Options{Exclude: []string{"topic:cli"}}, want: []string{"infra", "site"}},
+		{name: "commits", opts: Options{Rank: RankCommits}, err: "unsupported ranking 'commits'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects, err := Select(repos, tt.opts)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Select: %v", err)
+			}
+			var names []string
+			for _, proj := range projects {
+				names = append(names, proj.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("projects = %v, want %v", names, tt.want)
+			}
+		})
+	}
+
+	projects, _ := Select(repos[:1], Options{})
+	if want := []string{"Go", "cli"}; !reflect.DeepEqual(projects[0].Technologies, want) {
+		t.Errorf("technologies = %v, want the language then the other topics %v", projects[0].Technologies, want)
+	}
+}
+
+func TestSetLanguages(t *testing.T) {
+	proj := Project{Technologies: []string{"Go", "kubernetes"}}
+	SetLanguages(&proj, map[string]int{"Shell": 600, "Go": 9000, "Makefile": 20, "Dockerfile": 600})
+
+	// Languages under 5% are left out, the other technologies kept last
+	if want := []string{"Go", "Dockerfile", "Shell", "kubernetes"}; !reflect.DeepEqual(proj.Technologies, want) {
+		t.Errorf("technologies = %v, want %v", proj.Technologies, want)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package generator
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"resumectl/internal/models"
+)
+
+var (
+	pageObject = regexp.MustCompile(`/Type\s*/Page[^s]`)
+	pageCount  = regexp.MustCompile(`/Type\s*/Pages\b[^>]*?/Count\s+(\d+)|/Count\s+(\d+)[^>]*?/Type\s*/Pages\b`)
+)
+
+// CountPages returns the number of pages of a PDF file
+func CountPages(pdfPath string) (int, error) {
+	data, err := os.ReadFile(pdfPath)
+	if err != nil {
+		return 0, err
+	}
+
+	if n := len(pageObject.FindAll(data, -1)); n > 0 {
+		return n, nil
+	}
+	// Page objects may be compressed in object streams, the page tree root
+	// is usually not
+	count := 0
+	for _, m := range pageCount.FindAllSubmatch(data, -1) {
+		for _, group := range m[1:] {
+			if n, err := strconv.Atoi(string(group)); err == nil {
+				count = max(count, n)
+			}
+		}
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("no page found in %s", pdfPath)
+	}
+	return count, nil
+}
+
+// fitStep is a way of shortening the CV. apply returns a description of
+// what it dropped, or "" when it changed nothing.
+type fitStep func(g *Generator) string
+
+// fitSteps shorten the CV, least important content first
+var fitSteps = []fitStep{
+	func(g *Generator) string {
+		g.compact = true
+		return "tightened spacing"
+	},
+	dropSection("interests", func(cv *models.CV) int {
+		n := len(cv.Interests)
+		cv.Interests = nil
+		return n
+	}),
+	limitHighlights(FitHighlights),
+	dropSection("projects", func(cv *models.CV) int {
+		n := len(cv.Projects)
+		cv.Projects = nil
+		return n
+	}),
+	dropSection("certifications", func(cv *models.CV) int {
+		n := len(cv.Certifications)
+		cv.Certifications = nil
+		return n
+	}),
+	limitHighlights(FitHighlights - 1),
+	dropOldestExperience,
+	dropOldestExperience,
+	dropOldestExperience,
+	limitHighlights(FitHighlights - 2),
+	dropSection("education descriptions", func(cv *models.CV) int {
+		n := 0
+		for i := range cv.Education {
+			if cv.Education[i].Description != "" {
+				cv.Education[i].Description = ""
+				n++
+			}
+		}
+		return n
+	}),
+}
+
+// FitHighlights is the number of highlights per experience kept by the
+// first fitting step limiting them; the later ones keep fewer
+const FitHighlights = 4
+
+// minExperiences is the number of experiences kept when fitting the pages
+const minExperiences = 2
+
+func dropSection(name string, drop func(cv *models.CV) int) fitStep {
+	return func(g *Generator) string {
+		if n := drop(g.cv); n > 0 {
+			return fmt.Sprintf("%s (%d)", name, n)
+		}
+		return ""
+	}
+}
+
+func limitHighlights(max int) fitStep {
+	return func(g *Generator) string {
+		n := 0
+		for i := range g.cv.Experience {
+			if highlights := g.cv.Experience[i].Highlights; len(highlights) > max {
+				n += len(highlights) - max
+				g.cv.Experience[i].Highlights = highlights[:max]
+			}
+		}
+		if n == 0 {
+			return ""
+		}
+		return fmt.Sprintf("highlights beyond %d per experience (%d)", max, n)
+	}
+}
+
+// dropOldestExperience drops the experience that started first. Experiences
+// without a readable start date are kept: their age is unknown.
+func dropOldestExperience(g *Generator) string {
+	if len(g.cv.Experience) <= minExperiences {
+		return ""
+	}
+	oldest := -1
+	var oldestStart time.Time
+	for i, exp := range g.cv.Experience {
+		// A year alone is taken as its first month
+		start, _, ok := models.ParseDate(exp.StartDate)
+		if ok && (oldest == -1 || start.Before(oldestStart)) {
+			oldest, oldestStart = i, start
+		}
+	}
+	if oldest == -1 {
+		return ""
+	}
+	exp := g.cv.Experience[oldest]
+	g.cv.Experience = append(g.cv.Experience[:oldest], g.cv.Experience[oldest+1:]...)
+	return fmt.Sprintf("experience at %s (%s)", exp.Company, exp.StartDate)
+}
+
+// FitPages generates the HTML and PDF files, then shortens the CV until
+// the PDF has at most maxPages pages: first by tightening the spacing, then
+// by dropping the least important content (interests, extra highlights,
+// projects, certifications, oldest experiences). It returns the final
+// number of pages and what was dropped, which may not be enough to fit.
+func (g *Generator) FitPages(htmlPath, pdfPath string, maxPages int) (int, []string, error) {
+	var dropped []string
+	for step := 0; ; step++ {
+		if err := g.GenerateHTML(htmlPath); err != nil {
+			return 0, dropped, err
+		}
+		if err := g.GeneratePDF(htmlPath, pdfPath); err != nil {
+			return 0, dropped, err
+		}
+		pages, err := CountPages(pdfPath)
+		if err != nil {
+			return 0, dropped, err
+		}
+
+		// Skip the steps that have nothing left to drop
+		for ; pages > maxPages && step < len(fitSteps); step++ {
+			if change := fitSteps[step](g); change != "" {
+				dropped = append(dropped, change)
+				break
+			}
+		}
+		if pages <= maxPages || step >= len(fitSteps) {
+			return pages, dropped, nil
+		}
+	}
+}
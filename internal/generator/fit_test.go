@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestCountPages(t *testing.T) {
+	tests := []struct {
+		file  string
+		pages int
+	}{
+		{"one.pdf", 1},
+		{"three.pdf", 3},      // "/Type/Page" without space
+		{"compressed.pdf", 2}, // Pages in an object stream, counted from the page tree
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			pages, err := CountPages(filepath.Join("testdata", "pages", tt.file))
+			if err != nil {
+				t.Fatalf("CountPages: %v", err)
+			}
+			if pages != tt.pages {
+				t.Errorf("CountPages = %d, want %d", pages, tt.pages)
+			}
+		})
+	}
+}
+
+func TestCountPagesErrors(t *testing.T) {
+	if _, err := CountPages(filepath.Join("testdata", "pages", "missing.pdf")); err == nil {
+		t.Error("CountPages of a missing file succeeded")
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.pdf")
+	if err := os.WriteFile(empty, []byte("%PDF-1.7\n%%EOF\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CountPages(empty); err == nil {
+		t.Error("CountPages of a PDF without pages succeeded")
+	}
+}
+
+// fakeConverter puts testdata/bin first in $PATH: its wkhtmltopdf makes a
+// PDF with one page per 10 list items of the HTML file
+func fakeConverter(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake PDF converter is a shell script")
+	}
+	bin, err := filepath.Abs(filepath.Join("testdata", "bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestFitPages(t *testing.T) {
+	// testdata/fit.yaml has 4 experiences of 6 highlights: 3 pages
+	fakeConverter(t)
+
+	tests := []struct {
+		maxPages    int
+		pages       int
+		dropped     []string
+		experiences []string
+	}{
+		{3, 3, nil, []string{"Current", "Previous", "First", "Freelance"}},
+		{2, 2, []string{
+			"tightened spacing",
+			"interests (2)",
+			"highlights beyond 4 per experience (8)",
+		}, []string{"Current", "Previous", "First", "Freelance"}},
+		// The oldest experience is found by date, whatever its format, and
+		// the undated one is kept
+		{1, 1, []string{
+			"tightened spacing",
+			"interests (2)",
+			"highlights beyond 4 per experience (8)",
+			"highlights beyond 3 per experience (4)",
+			"experience at First (2015-01)",
+		}, []string{"Current", "Previous", "Freelance"}},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		g, err := New(filepath.Join("testdata", "fit.yaml"), "modern", dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pages, dropped, err := g.FitPages(filepath.Join(dir, "cv.html"), filepath.Join(dir, "cv.pdf"), tt.maxPages)
+		if err != nil {
+			t.Fatalf("FitPages(%d): %v", tt.maxPages, err)
+		}
+		if pages != tt.pages {
+			t.Errorf("FitPages(%d) = %d pages, want %d", tt.maxPages, pages, tt.pages)
+		}
+		if !reflect.DeepEqual(dropped, tt.dropped) {
+			t.Errorf("FitPages(%d) dropped %q, want %q", tt.maxPages, dropped, tt.dropped)
+		}
+
+		var experiences []string
+		for _, exp := range g.GetCV().Experience {
+			experiences = append(experiences, exp.Company)
+		}
+		if !reflect.DeepEqual(experiences, tt.experiences) {
+			t.Errorf("FitPages(%d) kept %q, want %q", tt.maxPages, experiences, tt.experiences)
+		}
+	}
+}
+
+func TestFitPagesNotEnough(t *testing.T) {
+	fakeConverter(t)
+
+	// Nothing fits in 0 pages: every step is applied, dated experiences are
+	// dropped down to minExperiences and the undated one is kept
+	dir := t.TempDir()
+	g, err := New(filepath.Join("testdata", "fit.yaml"), "modern", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages, dropped, err := g.FitPages(filepath.Join(dir, "cv.html"), filepath.Join(dir, "cv.pdf"), 0)
+	if err != nil {
+		t.Fatalf("FitPages: %v", err)
+	}
+	if pages != 1 {
+		t.Errorf("FitPages = %d pages, want 1", pages)
+	}
+
+	var experiences []string
+	for _, exp := range g.GetCV().Experience {
+		experiences = append(experiences, exp.Company)
+	}
+	if want := []string{"Current", "Freelance"}; !reflect.DeepEqual(experiences, want) {
+		t.Errorf("kept %q, want %q", experiences, want)
+	}
+	if last := dropped[len(dropped)-1]; last != "highlights beyond 2 per experience (2)" {
+		t.Errorf("last change = %q", last)
+	}
+}
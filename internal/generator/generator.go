@@ -17,25 +17,42 @@ package generator
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
 	"html/template"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"resumectl/internal/jsonld"
 	"resumectl/internal/models"
+	"resumectl/internal/privacy"
+	"resumectl/internal/qrcode"
 	"resumectl/internal/templates"
+	"resumectl/internal/vcard"
 	"runtime"
+	"slices"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
+// Warn reports the problems that do not stop the generation, such as a
+// photo whose metadata cannot be read. They are discarded by default.
+var Warn = func(msg string, keyvals ...any) {}
+
 // Generator handles CV generation
 type Generator struct {
 	cv          *models.CV
 	theme       string
 	customColor string
 	outputDir   string
+	deps        []string // Files read to render the CV
+	photoPath   string   // Photo as given in the YAML file, before copyPhoto
+
+	structuredData bool   // Embed JSON-LD and microformats (default true)
+	pageURL        string // Address the HTML is published at, for JSON-LD
+	obfuscateEmail bool   // Assemble the email address with JavaScript
+	compact        bool   // Tighten the spacing of the theme (FitPages)
 }
 
 // New creates a new generator with the specified theme
@@ -49,6 +66,12 @@ func NewWithColor(yamlPath, theme, customColor, outputDir string) (*Generator, e
 	if err != nil {
 		return nil, fmt.Errorf("error loading CV: %w", err)
 	}
+	if err := privacy.Check(cv); err != nil {
+		return nil, err
+	}
+	if err := checkQRCode(cv.Personal.QRCode); err != nil {
+		return nil, err
+	}
 
 	// Validate theme
 	if theme == "" {
@@ -68,6 +91,10 @@ func NewWithColor(yamlPath, theme, customColor, outputDir string) (*Generator, e
 		theme:       theme,
 		customColor: customColor,
 		outputDir:   outputDir,
+		deps:        []string{yamlPath},
+		photoPath:   cv.Personal.Photo,
+
+		structuredData: true,
 	}, nil
 }
 
@@ -86,15 +113,25 @@ func loadCV(path string) (*models.CV, error) {
 	return &cv, nil
 }
 
-// GenerateHTML generates the HTML file
-func (g *Generator) GenerateHTML(outputPath string) error {
-	// Load template with theme
-	funcMap := template.FuncMap{
+// funcMap returns the functions of the HTML templates
+func (g *Generator) funcMap() template.FuncMap {
+	return template.FuncMap{
 		"formatDate": models.FormatDate,
+		"isCurrent":  models.IsCurrent,
+		"isoDate":    jsonld.ISODate,
 		"join":       strings.Join,
+		"jsonLD":     g.jsonLD,
+		"mfClass":    g.microformatClass,
+
+		"obfuscateEmail": g.obfuscatedEmail,
+		"qrCode":         g.qrCode,
 	}
+}
 
-	tmpl, err := templates.GetParsedTemplateWithColor(g.theme, g.customColor, funcMap)
+// GenerateHTML generates the HTML file
+func (g *Generator) GenerateHTML(outputPath string) error {
+	// Load template with theme
+	tmpl, err := templates.GetParsedTemplateWithColor(g.theme, g.customColor, g.funcMap())
 	if err != nil {
 		return fmt.Errorf("error loading template: %w", err)
 	}
@@ -104,26 +141,165 @@ func (g *Generator) GenerateHTML(outputPath string) error {
 		return fmt.Errorf("error creating directory: %w", err)
 	}
 
+	// Copy photo to output directory if specified, before the HTML refers
+	// to it
+	if err := g.copyPhoto(); err != nil {
+		return fmt.Errorf("error copying photo: %w", err)
+	}
+
 	// Generate HTML
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, g.cv); err != nil {
 		return fmt.Errorf("error executing template: %w", err)
 	}
+	html := buf.Bytes()
+	if g.compact {
+		css, err := templates.GetCompactCSS()
+		if err != nil {
+			return err
+		}
+		html = bytes.Replace(html, []byte("</head>"), []byte("<style>\n"+css+"</style>\n</head>"), 1)
+	}
 
 	// Write file
-	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
+	if err := os.WriteFile(outputPath, html, 0644); err != nil {
 		return fmt.Errorf("error writing file: %w", err)
 	}
 
-	// Copy photo to output directory if specified
-	if err := g.copyPhoto(); err != nil {
-		return fmt.Errorf("error copying photo: %w", err)
+	return nil
+}
+
+// SetStructuredData enables or disables the schema.org JSON-LD and the
+// h-resume/h-card microformat classes in the HTML output. They let search
+// engines and ATS crawlers parse the CV; disable them for privacy.
+func (g *Generator) SetStructuredData(enabled bool) {
+	g.structuredData = enabled
+}
+
+// SetPageURL sets the absolute URL the HTML is published at, used as the
+// url of the person in JSON-LD and to resolve the photo path
+func (g *Generator) SetPageURL(url string) {
+	g.pageURL = url
+}
+
+// Redact removes the given personal fields (see privacy.Fields), the fields
+// listed in personal.private and the entries marked private from the CV,
+// for a public version. Nothing is removed when fields is empty.
+func (g *Generator) Redact(fields []string) {
+	privacy.Redact(g.cv, fields)
+}
+
+// SetObfuscateEmail hides the email address from the HTML source: it is
+// stored encoded and assembled by a script in the browser, and left out
+// of JSON-LD
+func (g *Generator) SetObfuscateEmail(enabled bool) {
+	g.obfuscateEmail = enabled
+}
+
+// obfuscatedEmail renders the email link for the template when the address
+// is obfuscated, and nothing otherwise. The address is stored reversed and
+// base64-encoded; without JavaScript it reads "name [at] example [dot] com".
+func (g *Generator) obfuscatedEmail(email string) template.HTML {
+	if !g.obfuscateEmail || email == "" {
+		return ""
 	}
 
-	return nil
+	reversed := []rune(email)
+	slices.Reverse(reversed)
+	encoded := base64.StdEncoding.EncodeToString([]byte(string(reversed)))
+	fallback := strings.NewReplacer("@", " [at] ", ".", " [dot] ").Replace(email)
+
+	class := ""
+	if g.structuredData {
+		class = ` class="u-email"`
+	}
+	return template.HTML(`<a` + class + ` href="#" data-email="` + encoded + `">` + template.HTMLEscapeString(fallback) + `</a>` +
+		`<script>(function(a){var e=atob(a.getAttribute("data-email")).split("").reverse().join("");` +
+		`a.href="mailto:"+e;a.textContent=e;a.removeAttribute("data-email")})(document.currentScript.previousElementSibling)</script>`)
+}
+
+// qrBlock is the QR code block of the template
+type qrBlock struct {
+	SVG     template.HTML
+	Caption string
 }
 
-// copyPhoto copies the photo file to the output directory
+// checkQRCode validates the qrCode setting: empty, "vcard" or a URL
+func checkQRCode(value string) error {
+	if value == "" || strings.EqualFold(value, "vcard") ||
+		strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		return nil
+	}
+	return fmt.Errorf("personal.qrCode must be \"vcard\" or an http(s) URL, got %q", value)
+}
+
+// qrCode renders the QR code set by personal.qrCode for the template: the
+// vCard of p (without photo) or the URL of the online CV. It returns nil
+// when there is none.
+func (g *Generator) qrCode(p models.Personal) (*qrBlock, error) {
+	var data, caption string
+	switch {
+	case p.QRCode == "":
+		return nil, nil
+	case strings.EqualFold(p.QRCode, "vcard"):
+		card, err := vcard.Encode(p, false)
+		if err != nil {
+			return nil, err
+		}
+		data, caption = string(card), "Scan to save my contact"
+	default:
+		data = p.QRCode
+		caption = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(data, "https://"), "http://"), "/")
+	}
+
+	code, err := qrcode.Encode(data, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	return &qrBlock{SVG: template.HTML(code.SVG()), Caption: caption}, nil
+}
+
+// GenerateVCard writes the vCard of the CV, with the photo embedded
+func (g *Generator) GenerateVCard(outputPath string) error {
+	p := g.cv.Personal
+	if p.Photo != "" {
+		// copyPhoto may have replaced the path with one relative to the
+		// output directory
+		p.Photo = g.photoPath
+	}
+	return vcard.Write(p, outputPath)
+}
+
+// jsonLD renders the JSON-LD script of the CV for the template
+func (g *Generator) jsonLD(cv *models.CV) (template.HTML, error) {
+	if !g.structuredData {
+		return "", nil
+	}
+	if g.obfuscateEmail {
+		public := *cv
+		public.Personal.Email = ""
+		cv = &public
+	}
+	script, err := jsonld.Script(jsonld.Person(cv, g.pageURL))
+	return template.HTML(script), err
+}
+
+// microformatClass returns the class attribute of a template element: its
+// theme classes, followed by the microformat ones when structured data is
+// enabled. Elements left with no class get no attribute.
+func (g *Generator) microformatClass(theme, microformat string) template.HTMLAttr {
+	classes := theme
+	if g.structuredData {
+		classes = strings.TrimSpace(theme + " " + microformat)
+	}
+	if classes == "" {
+		return ""
+	}
+	return template.HTMLAttr(` class="` + template.HTMLEscapeString(classes) + `"`)
+}
+
+// copyPhoto copies the photo file to the output directory, without its
+// location data, and points the CV at the copy
 func (g *Generator) copyPhoto() error {
 	if g.cv.Personal.Photo == "" {
 		return nil
@@ -134,8 +310,9 @@ func (g *Generator) copyPhoto() error {
 		return nil
 	}
 
-	// Source path
-	srcPath := g.cv.Personal.Photo
+	// Source path, as given in the YAML file: Photo is rewritten once copied
+	srcPath := g.photoPath
+	g.deps = append(g.deps, srcPath)
 
 	// Check if source file exists
 	if _, err := os.Stat(srcPath); os.IsNotExist(err) {
@@ -152,8 +329,28 @@ func (g *Generator) copyPhoto() error {
 		return fmt.Errorf("error reading photo: %w", err)
 	}
 
-	// Write to destination
-	if err := os.WriteFile(dstPath, data, 0644); err != nil {
+	// The photo is published: drop the GPS position the camera may have
+	// saved, also when it already sits in the output directory
+	stripped, err := privacy.StripLocation(data)
+	if err != nil {
+		Warn("Photo metadata could not be read, location data may remain", "photo", srcPath, "error", err)
+		stripped = data
+	}
+
+	// The photo given in the YAML file is never modified: when it already
+	// sits in the output directory and has location data, the HTML points at
+	// a sanitized copy instead
+	if sameFile(srcPath, dstPath) {
+		if bytes.Equal(stripped, data) {
+			g.cv.Personal.Photo = filename
+			return nil
+		}
+		ext := filepath.Ext(filename)
+		filename = strings.TrimSuffix(filename, ext) + ".public" + ext
+		dstPath = filepath.Join(g.outputDir, filename)
+	}
+
+	if err := os.WriteFile(dstPath, stripped, 0644); err != nil {
 		return fmt.Errorf("error writing photo: %w", err)
 	}
 
@@ -163,6 +360,19 @@ func (g *Generator) copyPhoto() error {
 	return nil
 }
 
+// sameFile reports whether two paths point to the same existing file
+func sameFile(a, b string) bool {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(infoA, infoB)
+}
+
 // GeneratePDF generates the PDF file from HTML
 func (g *Generator) GeneratePDF(htmlPath, pdfPath string) error {
 	// Create output directory if needed
@@ -275,6 +485,13 @@ func (g *Generator) GetCV() *models.CV {
 	return g.cv
 }
 
+// Dependencies returns the files read to render the CV: the YAML file and
+// the photo, which may not exist yet. The list is complete once
+// GenerateHTML has run, even if it failed.
+func (g *Generator) Dependencies() []string {
+	return g.deps
+}
+
 // GetTheme returns the theme being used
 func (g *Generator) GetTheme() string {
 	return g.theme
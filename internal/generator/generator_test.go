@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package generator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"resumectl/internal/models"
+)
+
+// pngPhoto builds a PNG file, with an XMP chunk (which may hold a location)
+// when withXMP is set
+func pngPhoto(withXMP bool) []byte {
+	chunk := func(kind string, data []byte) []byte {
+		c := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+		c = append(c, kind...)
+		c = append(c, data...)
+		return append(c, 0, 0, 0, 0)
+	}
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = append(data, chunk("IHDR", make([]byte, 13))...)
+	if withXMP {
+		data = append(data, chunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00<x:xmpmeta/>"))...)
+	}
+	return append(data, chunk("IEND", nil)...)
+}
+
+func TestCopyPhoto(t *testing.T) {
+	tests := []struct {
+		name      string
+		inOutput  bool // The photo already sits in the output directory
+		withXMP   bool
+		wantPhoto string
+	}{
+		{name: "elsewhere", withXMP: true, wantPhoto: "photo.png"},
+		{name: "in output without metadata", inOutput: true, wantPhoto: "photo.png"},
+		{name: "in output with metadata", inOutput: true, withXMP: true, wantPhoto: "photo.public.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srcDir, outDir := t.TempDir(), t.TempDir()
+			if tt.inOutput {
+				srcDir = outDir
+			}
+			original := pngPhoto(tt.withXMP)
+			srcPath := filepath.Join(srcDir, "photo.png")
+			if err := os.WriteFile(srcPath, original, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			g := &Generator{
+				cv:        &models.CV{Personal: models.Personal{Photo: srcPath}},
+				outputDir: outDir,
+				photoPath: srcPath,
+			}
+			if err := g.copyPhoto(); err != nil {
+				t.Fatalf("copyPhoto: %v", err)
+			}
+
+			if g.cv.Personal.Photo != tt.wantPhoto {
+				t.Errorf("Photo = %q, want %q", g.cv.Personal.Photo, tt.wantPhoto)
+			}
+			if data, err := os.ReadFile(srcPath); err != nil || !bytes.Equal(data, original) {
+				t.Errorf("source photo modified (err %v)", err)
+			}
+			published, err := os.ReadFile(filepath.Join(outDir, tt.wantPhoto))
+			if err != nil {
+				t.Fatalf("reading published photo: %v", err)
+			}
+			if !bytes.Equal(published, pngPhoto(false)) {
+				t.Errorf("published photo =\n% x\nwant it without metadata", published)
+			}
+		})
+	}
+}
+
+func TestGenerateHTMLMicroformats(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		dir := t.TempDir()
+		g, err := New(filepath.Join("testdata", "fit.yaml"), "modern", dir)
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		g.SetStructuredData(enabled)
+
+		path := filepath.Join(dir, "cv.html")
+		if err := g.GenerateHTML(path); err != nil {
+			t.Fatalf("GenerateHTML: %v", err)
+		}
+		html, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := bytes.Contains(html, []byte(`<div class="container h-resume">`)); got != enabled {
+			t.Errorf("structured data %v: h-resume container = %v", enabled, got)
+		}
+		if !enabled && !bytes.Contains(html, []byte(`<div class="container">`)) {
+			t.Error("theme class dropped along with the microformats")
+		}
+		for _, broken := range []string{`class=""`, `class=" `, `&#34;`, `ZgotmplZ`} {
+			if bytes.Contains(html, []byte(broken)) {
+				t.Errorf("structured data %v: output contains %s", enabled, broken)
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"resumectl/internal/models"
+	"resumectl/internal/templates"
+
+	"gopkg.in/yaml.v3"
+)
+
+// letterPage is the data of the cover letter template. The CV is embedded
+// so that the shared header, and the body paragraphs, can use its fields.
+type letterPage struct {
+	*models.CV
+	Letter     *models.Letter
+	Date       string
+	Greeting   string
+	Paragraphs []string
+	Closing    string
+	WithCV     bool // Append the CV after a page break
+}
+
+// LoadLetter loads a cover letter from a YAML file
+func LoadLetter(path string) (*models.Letter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var letter models.Letter
+	if err := yaml.Unmarshal(data, &letter); err != nil {
+		return nil, fmt.Errorf("error loading letter: %w", err)
+	}
+	if len(letter.Body) == 0 {
+		return nil, fmt.Errorf("letter %s has no body", path)
+	}
+
+	return &letter, nil
+}
+
+// GenerateLetter generates the HTML of a cover letter with the theme and
+// header of the CV. With withCV, the CV follows the letter after a page
+// break, for a single PDF with both.
+func (g *Generator) GenerateLetter(letter *models.Letter, outputPath string, withCV bool) error {
+	page := &letterPage{
+		CV:       g.cv,
+		Letter:   letter,
+		Date:     letter.Date,
+		Greeting: letter.Greeting,
+		Closing:  letter.Closing,
+		WithCV:   withCV,
+	}
+	if page.Date == "" {
+		page.Date = time.Now().Format("January 2, 2006")
+	}
+	if page.Greeting == "" {
+		page.Greeting = "Dear Hiring Manager,"
+		if name, _, _ := strings.Cut(letter.Recipient, ","); name != "" {
+			page.Greeting = "Dear " + strings.TrimSpace(name) + ","
+		}
+	}
+	if page.Closing == "" {
+		page.Closing = "Sincerely,"
+	}
+
+	for i, paragraph := range letter.Body {
+		text, err := expandParagraph(paragraph, page)
+		if err != nil {
+			return fmt.Errorf("letter paragraph %d: %w", i+1, err)
+		}
+		page.Paragraphs = append(page.Paragraphs, text)
+	}
+
+	tmpl, err := templates.GetParsedLetterTemplateWithColor(g.theme, g.customColor, g.funcMap())
+	if err != nil {
+		return fmt.Errorf("error loading template: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+
+	if err := g.copyPhoto(); err != nil {
+		return fmt.Errorf("error copying photo: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		return fmt.Errorf("error executing template: %w", err)
+	}
+
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+
+	return nil
+}
+
+// expandParagraph executes the references to the CV and the letter in a
+// paragraph of the letter body
+func expandParagraph(paragraph string, page *letterPage) (string, error) {
+	tmpl, err := template.New("paragraph").Funcs(template.FuncMap{
+		"formatDate": models.FormatDate,
+		"join":       strings.Join,
+	}).Option("missingkey=error").Parse(paragraph)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, page); err != nil {
+		return "", err
+	}
+	return strings.Join(strings.Fields(buf.String()), " "), nil
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"resumectl/internal/forge"
+)
+
+// maxPages bounds pagination (50 repositories per page)
+const maxPages = 20
+
+// Source imports projects from a Gitea or Forgejo instance (Codeberg included)
+type Source struct {
+	name   string
+	client forge.Client
+}
+
+// New creates a Gitea/Forgejo source. name is used in messages ("Gitea",
+// "Forgejo"), baseURL is the web URL of the instance and token an optional
+// access token.
+func New(name, baseURL, token string) *Source {
+	s := &Source{
+		name:   name,
+		client: forge.Client{BaseURL: strings.TrimSuffix(baseURL, "/") + "/api/v1"},
+	}
+	if token != "" {
+		s.client.Authorize = func(req *http.Request) {
+			req.Header.Set("Authorization", "token "+token)
+		}
+	}
+	return s
+}
+
+// repository is the subset of the Gitea repository API used here
+type repository struct {
+	Name        string    `json:"name"`
+	FullName    string    `json:"full_name"`
+	Description string    `json:"description"`
+	HTMLURL     string    `json:"html_url"`
+	Stars       int       `json:"stars_count"`
+	Forks       int       `json:"forks_count"`
+	Language    string    `json:"language"`
+	Topics      []string  `json:"topics"`
+	Fork        bool      `json:"fork"`
+	Archived    bool      `json:"archived"`
+	Private     bool      `json:"private"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Name implements forge.ProjectSource
+func (s *Source) Name() string {
+	return s.name
+}
+
+// FetchProjects implements forge.ProjectSource. IncludeOrgs adds the
+// repositories of the organizations the user belongs to.
+func (s *Source) FetchProjects(username string, opts forge.Options) ([]forge.Project, error) {
+	opts.Defaults()
+	if opts.Rank != forge.RankStars && opts.Rank != forge.RankRecent {
+		return nil, fmt.Errorf("ranking '%s' is not supported for %s (use stars or recent)", opts.Rank, s.name)
+	}
+
+	repos, err := s.listRepos(fmt.Sprintf("/users/%s/repos", username))
+	if err == forge.ErrNotFound {
+		return nil, fmt.Errorf("%s user '%s' not found", s.name, username)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeOrgs {
+		var orgs []struct {
+			Username string `json:"username"`
+		}
+		if _, err := s.client.Get(fmt.Sprintf("/users/%s/orgs", username), &orgs); err != nil {
+			return nil, fmt.Errorf("error fetching organizations: %w", err)
+		}
+		for _, org := range orgs {
+			orgRepos, err := s.listRepos(fmt.Sprintf("/orgs/%s/repos", org.Username))
+			if err != nil {
+				return nil, fmt.Errorf("error fetching repositories of %s: %w", org.Username, err)
+			}
+			repos = append(repos, orgRepos...)
+		}
+	}
+
+	if forge.DebugMode {
+		fmt.Printf("[DEBUG] Found %d %s repositories for user %s\n", len(repos), s.name, username)
+	}
+
+	seen := make(map[string]bool)
+	var candidates []forge.Repo
+	for _, r := range repos {
+		if r.Private || seen[r.FullName] {
+			continue
+		}
+		seen[r.FullName] = true
+		candidates = append(candidates, forge.Repo{
+			ID:          r.FullName,
+			Name:        r.Name,
+			Description: r.Description,
+			URL:         r.HTMLURL,
+			Language:    r.Language,
+			Topics:      r.Topics,
+			Stars:       r.Stars,
+			Forks:       r.Forks,
+			Fork:        r.Fork,
+			Archived:    r.Archived,
+			PushedAt:    r.UpdatedAt,
+		})
+	}
+
+	selected, err := forge.Select(candidates, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range selected {
+		var languages map[string]int
+		if _, err := s.client.Get(fmt.Sprintf("/repos/%s/languages", selected[i].ID), &languages); err != nil {
+			if forge.DebugMode {
+				fmt.Printf("[DEBUG] Could not fetch languages of %s: %v\n", selected[i].Name, err)
+			}
+			continue
+		}
+		forge.SetLanguages(&selected[i], languages)
+	}
+
+	return selected, nil
+}
+
+// listRepos fetches all pages of a repository listing endpoint
+func (s *Source) listRepos(endpoint string) ([]repository, error) {
+	var all []repository
+	for page := 1; page <= maxPages; page++ {
+		var repos []repository
+		if _, err := s.client.Get(fmt.Sprintf("%s?limit=50&page=%d", endpoint, page), &repos); err != nil {
+			return nil, err
+		}
+		all = append(all, repos...)
+		if len(repos) < 50 {
+			break
+		}
+	}
+	return all, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package gitea
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"resumectl/internal/forge"
+)
+
+// newServer starts a fake Forgejo instance where user "jane" has a full
+// first page of 50 small repositories, a second page, and an organization
+func newServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+
+	var first []string
+	for i := 0; i < 50; i++ {
+		first = append(first, fmt.Sprintf(`{"name": "repo%d", "full_name": "jane/repo%d", "stars_count": 1}`, i, i))
+	}
+	first[0] = `{"name": "tool", "full_name": "jane/tool", "html_url": "https://codeberg.org/jane/tool", "stars_count": 50, "language": "Go"}`
+	first[1] = `{"name": "secret", "full_name": "jane/secret", "stars_count": 99, "private": true}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := ""
+		if token != "" {
+			want = "token " + token
+		}
+		if got := r.Header.Get("Authorization"); got != want {
+			t.Errorf("%s: Authorization = %q, want %q", r.URL.Path, got, want)
+		}
+
+		switch r.URL.Path {
+		case "/api/v1/users/jane/repos":
+			if r.URL.Query().Get("limit") != "50" {
+				t.Errorf("repositories listed without limit=50: %s", r.URL)
+			}
+			switch r.URL.Query().Get("page") {
+			case "1":
+				io.WriteString(w, "["+strings.Join(first, ",")+"]")
+			case "2":
+				io.WriteString(w, `[{"name": "infra", "full_name": "jane/infra", "stars_count": 20, "topics": ["terraform"]}]`)
+			default:
+				t.Errorf("page after the last one requested: %s", r.URL)
+				io.WriteString(w, `[]`)
+			}
+
+		case "/api/v1/users/jane/orgs":
+			io.WriteString(w, `[{"username": "acme"}]`)
+
+		case "/api/v1/orgs/acme/repos":
+			io.WriteString(w, `[{"name": "platform", "full_name": "acme/platform", "stars_count": 30}, {"name": "tool", "full_name": "jane/tool", "stars_count": 50}]`)
+
+		case "/api/v1/repos/jane/tool/languages":
+			io.WriteString(w, `{"Go": 9000, "Makefile": 1000}`)
+
+		default:
+			if strings.HasSuffix(r.URL.Path, "/languages") {
+				io.WriteString(w, `{}`)
+				return
+			}
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchProjects(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		opts  forge.Options
+		want  []string
+	}{
+		{name: "stars, every page, no private repository", opts: forge.Options{Count: 3}, want: []string{"tool", "infra", "repo2"}},
+		{name: "token", token: "secret", opts: forge.Options{Count: 2}, want: []string{"tool", "infra"}},
+		{name: "organizations", opts: forge.Options{Count: 3, IncludeOrgs: true}, want: []string{"tool", "platform", "infra"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newServer(t, tt.token)
+
+			source := New("Forgejo", srv.URL+"/", tt.token)
+			projects, err := source.FetchProjects("jane", tt.opts)
+			if err != nil {
+				t.Fatalf("FetchProjects: %v", err)
+			}
+
+			var names []string
+			for _, proj := range projects {
+				names = append(names, proj.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("projects = %v, want %v", names, tt.want)
+			}
+			if want := []string{"Go", "Makefile"}; !reflect.DeepEqual(projects[0].Technologies, want) {
+				t.Errorf("technologies = %v, want %v", projects[0].Technologies, want)
+			}
+		})
+	}
+}
+
+func TestFetchProjectsErrors(t *testing.T) {
+	srv := newServer(t, "")
+	source := New("Forgejo", srv.URL, "")
+
+	if source.Name() != "Forgejo" {
+		t.Errorf("Name() = %q, want Forgejo", source.Name())
+	}
+	if _, err := source.FetchProjects("nobody", forge.Options{}); err == nil || err.Error() != "Forgejo user 'nobody' not found" {
+		t.Errorf("unknown user: err = %v", err)
+	}
+	if _, err := source.FetchProjects("jane", forge.Options{Rank: forge.RankPinned}); err == nil || err.Error() != "ranking 'pinned' is not supported for Forgejo (use stars or recent)" {
+		t.Errorf("pinned ranking: err = %v", err)
+	}
+}
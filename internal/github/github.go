@@ -16,29 +16,111 @@
 package github
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"resumectl/internal/forge"
 )
 
 // DebugMode enables debug output
 var DebugMode bool
 
+// APIBaseURL is the GitHub API root. For GitHub Enterprise Server use
+// https://<host>/api/v3
+var APIBaseURL = "https://api.github.com"
+
+// Token is an optional personal access token. Authenticated requests get a
+// rate limit of 5,000 requests per hour instead of 60.
+var Token string
+
+// MaxRateLimitWait is the longest the client sleeps for the rate limit to
+// reset before giving up with an error
+var MaxRateLimitWait = time.Minute
+
+// HTTPClient is the client used for all GitHub requests
+var HTTPClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+// ResponseCache stores responses by key (the URL and the token they were
+// fetched with) so that they can be revalidated with conditional requests
+// (ETag / If-None-Match). Not-modified responses do not count against the
+// rate limit.
+type ResponseCache interface {
+	Get(key string) (etag string, body []byte, ok bool)
+	Set(key, etag string, body []byte)
+}
+
+// Cache is the response cache used by the client (in-memory by default)
+var Cache ResponseCache = &memoryCache{entries: make(map[string]cacheEntry)}
+
+type cacheEntry struct {
+	etag string
+	body []byte
+}
+
+// memoryCache is a ResponseCache living for the duration of the process
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func (c *memoryCache) Get(key string) (string, []byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	return e.etag, e.body, ok
+}
+
+func (c *memoryCache) Set(key, etag string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{etag: etag, body: body}
+}
+
+// RateLimitError is returned when the API rate limit is exhausted and the
+// reset is too far away to wait for
+type RateLimitError struct {
+	Limit         int // Requests per hour, 0 if unknown (Retry-After)
+	Reset         time.Time
+	Authenticated bool
+}
+
+func (e *RateLimitError) Error() string {
+	msg := "GitHub API rate limit exceeded"
+	if e.Limit > 0 {
+		msg += fmt.Sprintf(" (%d requests/hour)", e.Limit)
+	}
+	msg += fmt.Sprintf(", resets at %s (in %s)", e.Reset.Format("15:04:05"), time.Until(e.Reset).Round(time.Second))
+	if !e.Authenticated {
+		msg += ". Set GITHUB_TOKEN or use --github-token for a higher limit"
+	}
+	return msg
+}
+
 // Repository represents a GitHub repository
 type Repository struct {
-	Name            string   `json:"name"`
-	FullName        string   `json:"full_name"`
-	Description     string   `json:"description"`
-	HTMLURL         string   `json:"html_url"`
-	StargazersCount int      `json:"stargazers_count"`
-	ForksCount      int      `json:"forks_count"`
-	Language        string   `json:"language"`
-	Topics          []string `json:"topics"`
-	Fork            bool     `json:"fork"`
-	Archived        bool     `json:"archived"`
+	Name            string    `json:"name"`
+	FullName        string    `json:"full_name"`
+	Description     string    `json:"description"`
+	HTMLURL         string    `json:"html_url"`
+	StargazersCount int       `json:"stargazers_count"`
+	ForksCount      int       `json:"forks_count"`
+	Language        string    `json:"language"`
+	Topics          []string  `json:"topics"`
+	Fork            bool      `json:"fork"`
+	Archived        bool      `json:"archived"`
+	PushedAt        time.Time `json:"pushed_at"`
 }
 
 // GitHubProfile represents a GitHub user profile
@@ -54,113 +136,55 @@ type GitHubProfile struct {
 }
 
 // Project represents a project to add to CV
-type Project struct {
-	Name         string
-	Description  string
-	URL          string
-	Technologies []string
-	Stars        int
-}
+type Project = forge.Project
 
-// FetchTopProjects fetches the top N projects by stars from a GitHub user
-func FetchTopProjects(username string, count int) ([]Project, error) {
-	if count <= 0 {
-		count = 5
-	}
-
-	// Fetch user's repositories
-	repos, err := fetchUserRepos(username)
-	if err != nil {
-		return nil, err
-	}
-
-	if DebugMode {
-		fmt.Printf("[DEBUG] Found %d repositories for user %s\n", len(repos), username)
-	}
+// Source imports projects from GitHub (or GitHub Enterprise when BaseURL is set)
+type Source struct {
+	BaseURL string // Web URL of a GitHub Enterprise server (default: github.com)
+	Token   string // Personal access token (default: Token)
+}
 
-	// Filter out forks and archived repos, then sort by stars
-	var ownRepos []Repository
-	for _, repo := range repos {
-		if !repo.Fork && !repo.Archived {
-			ownRepos = append(ownRepos, repo)
-		}
-	}
+// Name implements forge.ProjectSource
+func (s Source) Name() string {
+	return "GitHub"
+}
 
-	if DebugMode {
-		fmt.Printf("[DEBUG] %d non-fork, non-archived repositories\n", len(ownRepos))
+// FetchProjects implements forge.ProjectSource
+func (s Source) FetchProjects(username string, opts forge.Options) ([]Project, error) {
+	c := defaultClient()
+	if s.BaseURL != "" && !strings.Contains(s.BaseURL, "github.com") {
+		c.apiBase = strings.TrimSuffix(s.BaseURL, "/") + "/api/v3"
 	}
-
-	// Sort by stars descending
-	sort.Slice(ownRepos, func(i, j int) bool {
-		return ownRepos[i].StargazersCount > ownRepos[j].StargazersCount
-	})
-
-	// Take top N
-	if len(ownRepos) > count {
-		ownRepos = ownRepos[:count]
+	if s.Token != "" {
+		c.token = s.Token
 	}
+	return c.fetchProjects(username, opts)
+}
 
-	// Convert to Project structs
-	var projects []Project
-	for _, repo := range ownRepos {
-		proj := Project{
-			Name:        repo.Name,
-			Description: repo.Description,
-			URL:         repo.HTMLURL,
-			Stars:       repo.StargazersCount,
-		}
-
-		// Add language as technology if present
-		if repo.Language != "" {
-			proj.Technologies = append(proj.Technologies, repo.Language)
-		}
-
-		// Add topics as technologies
-		for _, topic := range repo.Topics {
-			// Avoid duplicates
-			if !containsIgnoreCase(proj.Technologies, topic) {
-				proj.Technologies = append(proj.Technologies, topic)
-			}
-		}
-
-		if DebugMode {
-			fmt.Printf("[DEBUG] Adding project: %s (⭐ %d)\n", repo.Name, repo.StargazersCount)
-		}
+// client performs the API requests of an import, so that sources for
+// different servers or accounts do not share their settings
+type client struct {
+	apiBase string // API root, without trailing slash
+	token   string // Personal access token, may be empty
+}
 
-		projects = append(projects, proj)
-	}
+// defaultClient returns a client using APIBaseURL and Token
+func defaultClient() *client {
+	return &client{apiBase: strings.TrimSuffix(APIBaseURL, "/"), token: Token}
+}
 
-	return projects, nil
+// FetchTopProjects fetches the top N projects by stars from a GitHub user
+func FetchTopProjects(username string, count int) ([]Project, error) {
+	return FetchProjects(username, Options{Count: count})
 }
 
 // FetchProfile fetches basic GitHub profile information
 func FetchProfile(username string) (*GitHubProfile, error) {
-	url := fmt.Sprintf("https://api.github.com/users/%s", username)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "resumectl/1.0")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusNotFound {
+	c := defaultClient()
+	body, err := c.get(fmt.Sprintf("%s/users/%s", c.apiBase, username))
+	if err == errNotFound {
 		return nil, fmt.Errorf("GitHub user '%s' not found", username)
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -174,38 +198,18 @@ func FetchProfile(username string) (*GitHubProfile, error) {
 }
 
 // fetchUserRepos fetches all public repositories for a user
-func fetchUserRepos(username string) ([]Repository, error) {
+func (c *client) fetchUserRepos(username string) ([]Repository, error) {
 	var allRepos []Repository
 	page := 1
 	perPage := 100
 
 	for {
-		url := fmt.Sprintf("https://api.github.com/users/%s/repos?per_page=%d&page=%d&sort=pushed", username, perPage, page)
-
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		req.Header.Set("Accept", "application/vnd.github.v3+json")
-		req.Header.Set("User-Agent", "resumectl/1.0")
-
-		client := &http.Client{}
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
+		url := fmt.Sprintf("%s/users/%s/repos?per_page=%d&page=%d&sort=pushed", c.apiBase, username, perPage, page)
 
-		if resp.StatusCode == http.StatusNotFound {
+		body, err := c.get(url)
+		if err == errNotFound {
 			return nil, fmt.Errorf("GitHub user '%s' not found", username)
 		}
-
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
-		}
-
-		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			return nil, err
 		}
@@ -236,15 +240,142 @@ func fetchUserRepos(username string) ([]Repository, error) {
 	return allRepos, nil
 }
 
-// containsIgnoreCase checks if a slice contains a string (case insensitive)
-func containsIgnoreCase(slice []string, str string) bool {
-	strLower := strings.ToLower(str)
-	for _, s := range slice {
-		if strings.ToLower(s) == strLower {
-			return true
+// errNotFound is returned by get for 404 responses
+var errNotFound = errors.New("not found")
+
+// get performs a GET API request
+func (c *client) get(url string) ([]byte, error) {
+	return c.do("GET", url, nil)
+}
+
+// cacheKey returns the key of a response in Cache: responses fetched with
+// another token (or none) may differ, e.g. private repositories
+func (c *client) cacheKey(url string) string {
+	if c.token == "" {
+		return url
+	}
+	sum := sha256.Sum256([]byte(c.token))
+	return url + " token:" + hex.EncodeToString(sum[:8])
+}
+
+// do performs an API request, revalidating cached GET responses with their
+// ETag and waiting once for the rate limit to reset when it is close enough
+func (c *client) do(method, url string, payload []byte) ([]byte, error) {
+	waited := false
+	for {
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequest(method, url, reqBody)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+		req.Header.Set("User-Agent", "resumectl/1.0")
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+
+		var cachedETag string
+		var cachedBody []byte
+		var cached bool
+		if method == "GET" {
+			cachedETag, cachedBody, cached = Cache.Get(c.cacheKey(url))
+		}
+		if cached && cachedETag != "" {
+			req.Header.Set("If-None-Match", cachedETag)
+		}
+
+		resp, err := HTTPClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if DebugMode {
+			fmt.Printf("[DEBUG] %s %s: %d (rate limit remaining: %s)\n", method, url, resp.StatusCode, resp.Header.Get("X-RateLimit-Remaining"))
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusNotModified && cached:
+			return cachedBody, nil
+
+		case resp.StatusCode == http.StatusOK:
+			if etag := resp.Header.Get("ETag"); etag != "" && method == "GET" {
+				Cache.Set(c.cacheKey(url), etag, body)
+			}
+			return body, nil
+
+		case resp.StatusCode == http.StatusNoContent:
+			return nil, nil
+
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, errNotFound
+
+		case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests:
+			wait, rateErr := rateLimitWait(resp, c.token != "")
+			if rateErr == nil {
+				return nil, fmt.Errorf("GitHub API returned status %d: %s", resp.StatusCode, apiErrorMessage(body))
+			}
+			// A reset already past that still answers 403 is not worth a
+			// second wait
+			if waited || wait > MaxRateLimitWait {
+				return nil, rateErr
+			}
+			waited = true
+			if DebugMode {
+				fmt.Printf("[DEBUG] Rate limited, waiting %s\n", wait)
+			}
+			time.Sleep(wait)
+
+		default:
+			return nil, fmt.Errorf("GitHub API returned status %d: %s", resp.StatusCode, apiErrorMessage(body))
 		}
 	}
-	return false
+}
+
+// rateLimitWait inspects a 403/429 response. It returns a nil error when the
+// response is not caused by rate limiting, and otherwise how long to wait.
+func rateLimitWait(resp *http.Response, authenticated bool) (time.Duration, *RateLimitError) {
+	// Secondary rate limits come with Retry-After
+	if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		wait := time.Duration(retryAfter) * time.Second
+		return wait, &RateLimitError{Reset: time.Now().Add(wait), Authenticated: authenticated}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, nil
+	}
+
+	rateErr := &RateLimitError{Authenticated: authenticated}
+	rateErr.Limit, _ = strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		rateErr.Reset = time.Now().Add(time.Hour)
+		return time.Hour, rateErr
+	}
+	rateErr.Reset = time.Unix(reset, 0)
+
+	// Add a second of margin for clock skew
+	return max(time.Until(rateErr.Reset)+time.Second, 0), rateErr
+}
+
+// apiErrorMessage extracts the "message" field of a GitHub error response
+func apiErrorMessage(body []byte) string {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+	return strings.TrimSpace(string(body))
 }
 
 // ExtractUsernameFromURL extracts the username from a GitHub URL or returns as-is
@@ -264,6 +395,14 @@ func ExtractUsernameFromURL(input string) string {
 		}
 	}
 
+	// Handle GitHub Enterprise URLs (https://git.example.com/username)
+	if idx := strings.Index(input, "://"); idx != -1 {
+		parts := strings.Split(input[idx+3:], "/")
+		if len(parts) > 1 && parts[1] != "" {
+			return parts[1]
+		}
+	}
+
 	// Return as-is if it's just a username
 	return input
 }
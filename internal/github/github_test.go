@@ -0,0 +1,209 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newServer starts an API server and gives the tests an empty response cache
+func newServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	previous := Cache
+	Cache = &memoryCache{entries: make(map[string]cacheEntry)}
+	t.Cleanup(func() { Cache = previous })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClientToken(t *testing.T) {
+	var auth []string
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		io.WriteString(w, "{}")
+	})
+
+	for _, token := range []string{"", "secret"} {
+		c := &client{apiBase: srv.URL, token: token}
+		if _, err := c.get(srv.URL + "/users/jane"); err != nil {
+			t.Fatalf("get (token %q): %v", token, err)
+		}
+	}
+
+	if want := []string{"", "Bearer secret"}; strings.Join(auth, ",") != strings.Join(want, ",") {
+		t.Errorf("Authorization headers = %q, want %q", auth, want)
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   map[string]string
+		token    string
+		requests int // Requests expected before giving up
+		message  string
+	}{
+		{
+			name: "reset too far away",
+			header: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Limit":     "60",
+				"X-RateLimit-Reset":     strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+			},
+			requests: 1,
+			message:  "GitHub API rate limit exceeded (60 requests/hour)",
+		},
+		{
+			name: "reset already past",
+			header: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Reset":     strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10),
+			},
+			token:    "secret",
+			requests: 2,
+			message:  "GitHub API rate limit exceeded (5000 requests/hour)",
+		},
+		{
+			name:     "secondary rate limit",
+			header:   map[string]string{"Retry-After": "0"},
+			requests: 2,
+			message:  "GitHub API rate limit exceeded, resets at",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				for name, value := range tt.header {
+					w.Header().Set(name, value)
+				}
+				w.WriteHeader(http.StatusForbidden)
+				io.WriteString(w, `{"message": "API rate limit exceeded"}`)
+			})
+
+			c := &client{apiBase: srv.URL, token: tt.token}
+			_, err := c.get(srv.URL + "/users/jane/repos")
+
+			var rateErr *RateLimitError
+			if !errors.As(err, &rateErr) {
+				t.Fatalf("err = %v, want a *RateLimitError", err)
+			}
+			if rateErr.Authenticated != (tt.token != "") {
+				t.Errorf("Authenticated = %v, want %v", rateErr.Authenticated, tt.token != "")
+			}
+			if !strings.HasPrefix(err.Error(), tt.message) {
+				t.Errorf("err = %q, want prefix %q", err, tt.message)
+			}
+			if strings.Contains(err.Error(), "GITHUB_TOKEN") == (tt.token != "") {
+				t.Errorf("err = %q: the token hint must only be given without a token", err)
+			}
+			if requests != tt.requests {
+				t.Errorf("%d requests, want %d", requests, tt.requests)
+			}
+		})
+	}
+}
+
+func TestClientRateLimitRecovers(t *testing.T) {
+	requests := 0
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, `{"login": "jane"}`)
+	})
+
+	c := &client{apiBase: srv.URL}
+	body, err := c.get(srv.URL + "/users/jane")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if string(body) != `{"login": "jane"}` || requests != 2 {
+		t.Errorf("body = %q after %d requests, want the second response", body, requests)
+	}
+}
+
+func TestClientForbidden(t *testing.T) {
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"message": "Resource not accessible"}`)
+	})
+
+	c := &client{apiBase: srv.URL}
+	_, err := c.get(srv.URL + "/repos/jane/tool")
+
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) {
+		t.Fatalf("err = %v, a 403 with requests left is not a rate limit", err)
+	}
+	if want := "GitHub API returned status 403: Resource not accessible"; err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %q", err, want)
+	}
+}
+
+func TestClientETag(t *testing.T) {
+	var revalidated []string
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		etag := `"` + r.Header.Get("Authorization") + `"`
+		if r.Header.Get("If-None-Match") != "" {
+			revalidated = append(revalidated, r.Header.Get("Authorization"))
+			if r.Header.Get("If-None-Match") != etag {
+				t.Errorf("If-None-Match = %s, want %s: responses of another token were reused", r.Header.Get("If-None-Match"), etag)
+			}
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		io.WriteString(w, "repos for "+r.Header.Get("Authorization"))
+	})
+
+	url := srv.URL + "/users/jane/repos"
+	for _, token := range []string{"", "one", "two", "", "one", "two"} {
+		c := &client{apiBase: srv.URL, token: token}
+		body, err := c.get(url)
+		if err != nil {
+			t.Fatalf("get (token %q): %v", token, err)
+		}
+		want := "repos for "
+		if token != "" {
+			want += "Bearer " + token
+		}
+		if string(body) != want {
+			t.Errorf("body (token %q) = %q, want %q", token, body, want)
+		}
+	}
+
+	if want := []string{"", "Bearer one", "Bearer two"}; strings.Join(revalidated, ",") != strings.Join(want, ",") {
+		t.Errorf("revalidated = %q, want %q", revalidated, want)
+	}
+}
@@ -0,0 +1,401 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"resumectl/internal/forge"
+)
+
+// Ranking strategies, re-exported for convenience
+const (
+	RankStars   = forge.RankStars
+	RankRecent  = forge.RankRecent
+	RankCommits = forge.RankCommits
+	RankPinned  = forge.RankPinned
+)
+
+// RankStrategies lists the ranking strategies supported by GitHub
+var RankStrategies = forge.RankStrategies
+
+// maxCommitCandidates bounds the number of repositories whose commits are
+// counted with RankCommits (one API request each)
+const maxCommitCandidates = 30
+
+// Options controls which repositories FetchProjects selects
+type Options = forge.Options
+
+// FetchProjects selects the best projects of a GitHub user according to opts
+// and enriches them with their full language breakdown.
+//
+// Include and Exclude patterns are either name globs ("terraform-*") or
+// topics prefixed with "topic:" ("topic:kubernetes").
+func FetchProjects(username string, opts Options) ([]Project, error) {
+	return defaultClient().fetchProjects(username, opts)
+}
+
+func (c *client) fetchProjects(username string, opts Options) ([]Project, error) {
+	opts.Defaults()
+
+	// Only the profile owner pins repositories: organization ones would
+	// silently be left out
+	if opts.IncludeOrgs && opts.Rank == RankPinned {
+		return nil, fmt.Errorf("--include-orgs cannot be combined with the pinned ranking")
+	}
+
+	var repos []Repository
+	var err error
+	if opts.Rank == RankPinned {
+		repos, err = c.fetchPinnedRepos(username)
+	} else {
+		repos, err = c.fetchUserRepos(username)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeOrgs {
+		orgRepos, err := c.fetchOrgContributions(username)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching organization repositories: %w", err)
+		}
+		repos = appendUnique(repos, orgRepos...)
+	}
+
+	if DebugMode {
+		fmt.Printf("[DEBUG] Found %d repositories for user %s\n", len(repos), username)
+	}
+
+	// Filter out forks, archived repos and repos rejected by patterns
+	var candidates []Repository
+	for _, repo := range repos {
+		if opts.Keep(repo.Name, repo.Topics, repo.Fork, repo.Archived) {
+			candidates = append(candidates, repo)
+		}
+	}
+
+	if DebugMode {
+		fmt.Printf("[DEBUG] %d candidate repositories after filtering\n", len(candidates))
+	}
+
+	projects, err := c.rankRepos(username, candidates, opts.Rank)
+	if err != nil {
+		return nil, err
+	}
+
+	// Take top N
+	if len(projects) > opts.Count {
+		projects = projects[:opts.Count]
+	}
+
+	for i := range projects {
+		if err := c.enrichLanguages(&projects[i]); err != nil && DebugMode {
+			fmt.Printf("[DEBUG] Could not fetch languages of %s: %v\n", projects[i].Name, err)
+		}
+
+		if DebugMode {
+			fmt.Printf("[DEBUG] Adding project: %s (⭐ %d)\n", projects[i].Name, projects[i].Stars)
+		}
+	}
+
+	return projects, nil
+}
+
+// rankRepos converts repositories to projects ordered by the given strategy
+func (c *client) rankRepos(username string, repos []Repository, rank string) ([]Project, error) {
+	switch rank {
+	case RankStars:
+		sort.SliceStable(repos, func(i, j int) bool {
+			return repos[i].StargazersCount > repos[j].StargazersCount
+		})
+	case RankRecent:
+		sort.SliceStable(repos, func(i, j int) bool {
+			return repos[i].PushedAt.After(repos[j].PushedAt)
+		})
+	case RankCommits:
+		// Counting commits costs one request per repository: only consider the
+		// most recently pushed ones
+		sort.SliceStable(repos, func(i, j int) bool {
+			return repos[i].PushedAt.After(repos[j].PushedAt)
+		})
+		if len(repos) > maxCommitCandidates {
+			repos = repos[:maxCommitCandidates]
+		}
+	case RankPinned:
+		// Keep the order chosen on the profile
+	default:
+		return nil, fmt.Errorf("unknown ranking '%s' (available: %s)", rank, strings.Join(RankStrategies, ", "))
+	}
+
+	projects := make([]Project, 0, len(repos))
+	for _, repo := range repos {
+		projects = append(projects, toProject(repo))
+	}
+
+	if rank == RankCommits {
+		for i, repo := range repos {
+			commits, err := c.countCommits(repo.FullName, username)
+			var rateErr *RateLimitError
+			if errors.As(err, &rateErr) {
+				return nil, err
+			}
+			// e.g. "contributor list is too large" for huge repositories: the
+			// other repositories can still be ranked
+			if err != nil && DebugMode {
+				fmt.Printf("[DEBUG] Could not count commits of %s, counting none: %v\n", repo.FullName, err)
+			}
+			projects[i].Commits = commits
+		}
+		sort.SliceStable(projects, func(i, j int) bool {
+			return projects[i].Commits > projects[j].Commits
+		})
+	}
+
+	return projects, nil
+}
+
+// toProject converts a repository to a project, using its main language and
+// topics as technologies until the language breakdown is fetched
+func toProject(repo Repository) Project {
+	proj := Project{
+		ID:          repo.FullName,
+		Name:        repo.Name,
+		Description: repo.Description,
+		URL:         repo.HTMLURL,
+		Stars:       repo.StargazersCount,
+		Forks:       repo.ForksCount,
+		Topics:      repo.Topics,
+		PushedAt:    repo.PushedAt,
+	}
+
+	// Add language as technology if present
+	if repo.Language != "" {
+		proj.Technologies = append(proj.Technologies, repo.Language)
+	}
+
+	// Add topics as technologies
+	for _, topic := range repo.Topics {
+		// Avoid duplicates
+		if !forge.ContainsIgnoreCase(proj.Technologies, topic) {
+			proj.Technologies = append(proj.Technologies, topic)
+		}
+	}
+
+	return proj
+}
+
+// enrichLanguages fetches the language breakdown of a project and lists
+// every significant language in its technologies, largest first
+func (c *client) enrichLanguages(proj *Project) error {
+	body, err := c.get(fmt.Sprintf("%s/repos/%s/languages", c.apiBase, proj.ID))
+	if err != nil {
+		return err
+	}
+
+	var languages map[string]int
+	if err := json.Unmarshal(body, &languages); err != nil {
+		return err
+	}
+	forge.SetLanguages(proj, languages)
+
+	return nil
+}
+
+// countCommits returns the number of commits of username in a repository
+func (c *client) countCommits(fullName, username string) (int, error) {
+	body, err := c.get(fmt.Sprintf("%s/repos/%s/contributors?per_page=100", c.apiBase, fullName))
+	if err == errNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// Empty repositories answer 204 with no body
+	if len(body) == 0 {
+		return 0, nil
+	}
+
+	var contributors []struct {
+		Login         string `json:"login"`
+		Contributions int    `json:"contributions"`
+	}
+	if err := json.Unmarshal(body, &contributors); err != nil {
+		return 0, err
+	}
+
+	for _, contributor := range contributors {
+		if strings.EqualFold(contributor.Login, username) {
+			return contributor.Contributions, nil
+		}
+	}
+	return 0, nil
+}
+
+// fetchOrgContributions returns repositories of the user's public
+// organizations that contain commits authored by the user
+func (c *client) fetchOrgContributions(username string) ([]Repository, error) {
+	body, err := c.get(fmt.Sprintf("%s/users/%s/orgs", c.apiBase, username))
+	if err != nil {
+		return nil, err
+	}
+
+	var orgs []struct {
+		Login string `json:"login"`
+	}
+	if err := json.Unmarshal(body, &orgs); err != nil {
+		return nil, err
+	}
+
+	var repos []Repository
+	for _, org := range orgs {
+		query := url.QueryEscape(fmt.Sprintf("author:%s org:%s", username, org.Login))
+		body, err := c.get(fmt.Sprintf("%s/search/commits?q=%s&per_page=100", c.apiBase, query))
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Items []struct {
+				Repository Repository `json:"repository"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+
+		for _, item := range result.Items {
+			repos = appendUnique(repos, item.Repository)
+		}
+
+		if DebugMode {
+			fmt.Printf("[DEBUG] Organization %s: %d repositories with commits\n", org.Login, len(result.Items))
+		}
+	}
+
+	// Search results carry partial repository data: fetch full details
+	for i, repo := range repos {
+		body, err := c.get(fmt.Sprintf("%s/repos/%s", c.apiBase, repo.FullName))
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(body, &repos[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return repos, nil
+}
+
+// fetchPinnedRepos returns the repositories pinned on a profile, in order.
+// Pinned items are only exposed by the GraphQL API, which requires a token.
+func (c *client) fetchPinnedRepos(username string) ([]Repository, error) {
+	if c.token == "" {
+		return nil, fmt.Errorf("ranking by pinned repositories requires a GitHub token (GITHUB_TOKEN or --github-token)")
+	}
+
+	query := `query($login: String!) {
+  user(login: $login) {
+    pinnedItems(first: 6, types: REPOSITORY) {
+      nodes { ... on Repository { nameWithOwner } }
+    }
+  }
+}`
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": map[string]string{"login": username},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.do("POST", c.graphQLURL(), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data struct {
+			User *struct {
+				PinnedItems struct {
+					Nodes []struct {
+						NameWithOwner string `json:"nameWithOwner"`
+					} `json:"nodes"`
+				} `json:"pinnedItems"`
+			} `json:"user"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		return nil, fmt.Errorf("GitHub GraphQL API: %s", result.Errors[0].Message)
+	}
+	if result.Data.User == nil {
+		return nil, fmt.Errorf("GitHub user '%s' not found", username)
+	}
+
+	var repos []Repository
+	for _, node := range result.Data.User.PinnedItems.Nodes {
+		body, err := c.get(fmt.Sprintf("%s/repos/%s", c.apiBase, node.NameWithOwner))
+		if err != nil {
+			return nil, err
+		}
+		var repo Repository
+		if err := json.Unmarshal(body, &repo); err != nil {
+			return nil, err
+		}
+		repos = append(repos, repo)
+	}
+
+	return repos, nil
+}
+
+// graphQLURL derives the GraphQL endpoint from the API root
+// (https://api.github.com/graphql, or https://<host>/api/graphql on GHES)
+func (c *client) graphQLURL() string {
+	base := c.apiBase
+	if strings.HasSuffix(base, "/v3") {
+		return strings.TrimSuffix(base, "/v3") + "/graphql"
+	}
+	return base + "/graphql"
+}
+
+// appendUnique appends repositories not already present (by full name)
+func appendUnique(repos []Repository, more ...Repository) []Repository {
+	for _, repo := range more {
+		found := false
+		for _, existing := range repos {
+			if strings.EqualFold(existing.FullName, repo.FullName) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			repos = append(repos, repo)
+		}
+	}
+	return repos
+}
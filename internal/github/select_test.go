@@ -0,0 +1,240 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// repo renders a repository of the fake API
+func repo(fullName string, stars int, pushedAt string, extra string) string {
+	name := fullName[strings.Index(fullName, "/")+1:]
+	return fmt.Sprintf(`{"name": %q, "full_name": %q, "html_url": "https://github.com/%s", "stargazers_count": %d, "pushed_at": "%sT00:00:00Z"%s}`,
+		name, fullName, fullName, stars, pushedAt, extra)
+}
+
+// newAPI starts a fake GitHub API serving the repositories of "jane" and of
+// her organization "acme"
+func newAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	repos := map[string]string{
+		"jane/tool":     repo("jane/tool", 50, "2026-01-10", `, "language": "Go", "topics": ["cli"]`),
+		"jane/site":     repo("jane/site", 5, "2026-09-10", `, "language": "HTML"`),
+		"jane/infra":    repo("jane/infra", 20, "2026-06-10", `, "topics": ["terraform"]`),
+		"jane/huge":     repo("jane/huge", 1, "2026-08-10", ""),
+		"jane/fork":     repo("jane/fork", 100, "2026-09-01", `, "fork": true`),
+		"jane/old":      repo("jane/old", 80, "2020-01-01", `, "archived": true`),
+		"acme/platform": repo("acme/platform", 30, "2026-07-10", `, "language": "Go"`),
+	}
+	contributors := map[string]string{
+		"jane/tool":  `[{"login": "jane", "contributions": 10}]`,
+		"jane/site":  `[{"login": "Jane", "contributions": 40}]`,
+		"jane/infra": `[{"login": "bot", "contributions": 100}, {"login": "jane", "contributions": 25}]`,
+	}
+
+	return newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		switch {
+		case path == "/users/jane/repos":
+			var list []string
+			for _, name := range []string{"jane/tool", "jane/site", "jane/infra", "jane/huge", "jane/fork", "jane/old"} {
+				list = append(list, repos[name])
+			}
+			io.WriteString(w, "["+strings.Join(list, ",")+"]")
+
+		case path == "/users/jane/orgs":
+			io.WriteString(w, `[{"login": "acme"}]`)
+
+		case path == "/search/commits":
+			if q := r.URL.Query().Get("q"); q != "author:jane org:acme" {
+				t.Errorf("search query = %q", q)
+			}
+			// Search results only carry partial repository data
+			io.WriteString(w, `{"items": [{"repository": {"full_name": "acme/platform"}}, {"repository": {"full_name": "acme/platform"}}]}`)
+
+		case path == "/graphql":
+			if r.Method != http.MethodPost || r.Header.Get("Authorization") == "" {
+				t.Errorf("GraphQL request: %s with Authorization %q", r.Method, r.Header.Get("Authorization"))
+			}
+			io.WriteString(w, `{"data": {"user": {"pinnedItems": {"nodes": [{"nameWithOwner": "jane/site"}, {"nameWithOwner": "jane/tool"}]}}}}`)
+
+		case strings.HasSuffix(path, "/languages"):
+			io.WriteString(w, `{"Go": 900, "Shell": 100}`)
+
+		case strings.HasSuffix(path, "/contributors"):
+			name := strings.TrimSuffix(strings.TrimPrefix(path, "/repos/"), "/contributors")
+			if name == "jane/huge" {
+				w.Header().Set("X-RateLimit-Remaining", "4000")
+				w.WriteHeader(http.StatusForbidden)
+				io.WriteString(w, `{"message": "The history or contributor list is too large to list contributors for this repository via the API."}`)
+				return
+			}
+			body, ok := contributors[name]
+			if !ok {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			io.WriteString(w, body)
+
+		case strings.HasPrefix(path, "/repos/"):
+			body, ok := repos[strings.TrimPrefix(path, "/repos/")]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			io.WriteString(w, body)
+
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestFetchProjects(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		token   string
+		want    []string // Project names, in order
+		commits []int    // Commits of each project with RankCommits
+		err     string
+	}{
+		{
+			name: "stars",
+			opts: Options{Count: 3},
+			want: []string{"tool", "infra", "site"},
+		},
+		{
+			name: "recent",
+			opts: Options{Count: 3, Rank: RankRecent},
+			want: []string{"site", "huge", "infra"},
+		},
+		{
+			name:    "commits, contributor list too large",
+			opts:    Options{Rank: RankCommits},
+			want:    []string{"site", "infra", "tool", "huge"},
+			commits: []int{40, 25, 10, 0},
+		},
+		{
+			name: "include topic",
+			opts: Options{Include: []string{"topic:terraform", "si*"}},
+			want: []string{"infra", "site"},
+		},
+		{
+			name: "exclude glob",
+			opts: Options{Count: 3, Exclude: []string{"t*"}},
+			want: []string{"infra", "site", "huge"},
+		},
+		{
+			name: "organization repositories",
+			opts: Options{Count: 3, IncludeOrgs: true},
+			want: []string{"tool", "platform", "infra"},
+		},
+		{
+			name:  "pinned",
+			opts:  Options{Rank: RankPinned},
+			token: "secret",
+			want:  []string{"site", "tool"},
+		},
+		{
+			name: "pinned without token",
+			opts: Options{Rank: RankPinned},
+			err:  "ranking by pinned repositories requires a GitHub token",
+		},
+		{
+			name:  "pinned with organizations",
+			opts:  Options{Rank: RankPinned, IncludeOrgs: true},
+			token: "secret",
+			err:   "--include-orgs cannot be combined with the pinned ranking",
+		},
+		{
+			name: "unknown ranking",
+			opts: Options{Rank: "forks"},
+			err:  "unknown ranking 'forks'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newAPI(t)
+			c := &client{apiBase: srv.URL, token: tt.token}
+
+			projects, err := c.fetchProjects("jane", tt.opts)
+			if tt.err != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fetchProjects: %v", err)
+			}
+
+			var names []string
+			var commits []int
+			for _, proj := range projects {
+				names = append(names, proj.Name)
+				commits = append(commits, proj.Commits)
+				if !reflect.DeepEqual(proj.Technologies[:2], []string{"Go", "Shell"}) {
+					t.Errorf("%s technologies = %v, want the language breakdown first", proj.Name, proj.Technologies)
+				}
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("projects = %v, want %v", names, tt.want)
+			}
+			if tt.commits != nil && !reflect.DeepEqual(commits, tt.commits) {
+				t.Errorf("commits = %v, want %v", commits, tt.commits)
+			}
+		})
+	}
+}
+
+func TestFetchOrgContributions(t *testing.T) {
+	srv := newAPI(t)
+	c := &client{apiBase: srv.URL}
+
+	repos, err := c.fetchOrgContributions("jane")
+	if err != nil {
+		t.Fatalf("fetchOrgContributions: %v", err)
+	}
+	// Duplicate search hits are merged and completed with the full details
+	if len(repos) != 1 {
+		t.Fatalf("repos = %+v, want acme/platform once", repos)
+	}
+	if repos[0].StargazersCount != 30 || repos[0].HTMLURL != "https://github.com/acme/platform" {
+		t.Errorf("repository = %+v, want the full details", repos[0])
+	}
+}
+
+func TestGraphQLURL(t *testing.T) {
+	for apiBase, want := range map[string]string{
+		"https://api.github.com":         "https://api.github.com/graphql",
+		"https://ghe.example.com/api/v3": "https://ghe.example.com/api/graphql",
+	} {
+		c := &client{apiBase: apiBase}
+		if got := c.graphQLURL(); got != want {
+			t.Errorf("graphQLURL(%s) = %s, want %s", apiBase, got, want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"resumectl/internal/forge"
+)
+
+// DefaultBaseURL is the GitLab instance used when none is configured
+const DefaultBaseURL = "https://gitlab.com"
+
+// maxPages bounds pagination (100 projects per page)
+const maxPages = 10
+
+// Source imports projects from a GitLab instance
+type Source struct {
+	client forge.Client
+}
+
+// New creates a GitLab source. baseURL is the web URL of the instance
+// (default: gitlab.com); token is an optional personal access token.
+func New(baseURL, token string) *Source {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	s := &Source{client: forge.Client{BaseURL: strings.TrimSuffix(baseURL, "/") + "/api/v4"}}
+	if token != "" {
+		s.client.Authorize = func(req *http.Request) {
+			req.Header.Set("PRIVATE-TOKEN", token)
+		}
+	}
+	return s
+}
+
+// project is the subset of the GitLab project API used here
+type project struct {
+	ID             int       `json:"id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	WebURL         string    `json:"web_url"`
+	StarCount      int       `json:"star_count"`
+	ForksCount     int       `json:"forks_count"`
+	Topics         []string  `json:"topics"`
+	TagList        []string  `json:"tag_list"` // Topics on GitLab < 14.0
+	Archived       bool      `json:"archived"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	ForkedFrom     *struct{} `json:"forked_from_project"`
+}
+
+// Name implements forge.ProjectSource
+func (s *Source) Name() string {
+	return "GitLab"
+}
+
+// FetchProjects implements forge.ProjectSource. IncludeOrgs adds the
+// projects the user contributed to (including group projects).
+func (s *Source) FetchProjects(username string, opts forge.Options) ([]forge.Project, error) {
+	opts.Defaults()
+	if opts.Rank != forge.RankStars && opts.Rank != forge.RankRecent {
+		return nil, fmt.Errorf("ranking '%s' is not supported for GitLab (use stars or recent)", opts.Rank)
+	}
+
+	userID, err := s.userID(username)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := s.listProjects(fmt.Sprintf("/users/%d/projects", userID))
+	if err != nil {
+		return nil, err
+	}
+	if opts.IncludeOrgs {
+		contributed, err := s.listProjects(fmt.Sprintf("/users/%d/contributed_projects", userID))
+		if err != nil {
+			return nil, fmt.Errorf("error fetching contributed projects: %w", err)
+		}
+		projects = append(projects, contributed...)
+	}
+
+	if forge.DebugMode {
+		fmt.Printf("[DEBUG] Found %d GitLab projects for user %s\n", len(projects), username)
+	}
+
+	seen := make(map[int]bool)
+	var repos []forge.Repo
+	for _, p := range projects {
+		if seen[p.ID] {
+			continue
+		}
+		seen[p.ID] = true
+
+		topics := p.Topics
+		if len(topics) == 0 {
+			topics = p.TagList
+		}
+		repos = append(repos, forge.Repo{
+			ID:          strconv.Itoa(p.ID),
+			Name:        p.Name,
+			Description: p.Description,
+			URL:         p.WebURL,
+			Topics:      topics,
+			Stars:       p.StarCount,
+			Forks:       p.ForksCount,
+			Fork:        p.ForkedFrom != nil,
+			Archived:    p.Archived,
+			PushedAt:    p.LastActivityAt,
+		})
+	}
+
+	selected, err := forge.Select(repos, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// GitLab reports languages as percentages
+	for i := range selected {
+		var languages map[string]float64
+		if _, err := s.client.Get(fmt.Sprintf("/projects/%s/languages", selected[i].ID), &languages); err != nil {
+			if forge.DebugMode {
+				fmt.Printf("[DEBUG] Could not fetch languages of %s: %v\n", selected[i].Name, err)
+			}
+			continue
+		}
+		shares := make(map[string]int, len(languages))
+		for name, percent := range languages {
+			shares[name] = int(percent * 100)
+		}
+		forge.SetLanguages(&selected[i], shares)
+	}
+
+	return selected, nil
+}
+
+// userID resolves a username to its numeric ID
+func (s *Source) userID(username string) (int, error) {
+	var users []struct {
+		ID int `json:"id"`
+	}
+	if _, err := s.client.Get("/users?username="+url.QueryEscape(username), &users); err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, fmt.Errorf("GitLab user '%s' not found", username)
+	}
+	return users[0].ID, nil
+}
+
+// listProjects fetches all pages of a project listing endpoint
+func (s *Source) listProjects(endpoint string) ([]project, error) {
+	var all []project
+	for page := 1; page <= maxPages; page++ {
+		var projects []project
+		headers, err := s.client.Get(fmt.Sprintf("%s?per_page=100&page=%d&visibility=public", endpoint, page), &projects)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, projects...)
+		if headers.Get("X-Next-Page") == "" || len(projects) == 0 {
+			break
+		}
+	}
+	return all, nil
+}
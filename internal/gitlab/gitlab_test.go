@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package gitlab
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"resumectl/internal/forge"
+)
+
+// newServer starts a fake GitLab instance served under /gitlab, where user
+// "jane" (ID 7) has two pages of projects and contributed to a group project
+func newServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+
+	pages := map[string]string{
+		"1": `[{"id": 1, "name": "tool", "web_url": "https://git.example.com/jane/tool", "star_count": 50, "topics": ["cli"]},
+		       {"id": 2, "name": "fork", "star_count": 90, "forked_from_project": {"id": 9}}]`,
+		"2": `[{"id": 3, "name": "infra", "star_count": 20, "tag_list": ["terraform"]},
+		       {"id": 4, "name": "old", "star_count": 80, "archived": true}]`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("PRIVATE-TOKEN"); got != token {
+			t.Errorf("%s: PRIVATE-TOKEN = %q, want %q", r.URL.Path, got, token)
+		}
+
+		switch r.URL.Path {
+		case "/gitlab/api/v4/users":
+			if r.URL.Query().Get("username") == "jane" {
+				io.WriteString(w, `[{"id": 7}]`)
+				return
+			}
+			io.WriteString(w, `[]`)
+
+		case "/gitlab/api/v4/users/7/projects":
+			page := r.URL.Query().Get("page")
+			if r.URL.Query().Get("visibility") != "public" {
+				t.Errorf("projects listed without visibility=public: %s", r.URL)
+			}
+			if page == "1" {
+				w.Header().Set("X-Next-Page", "2")
+			}
+			io.WriteString(w, pages[page])
+
+		case "/gitlab/api/v4/users/7/contributed_projects":
+			// The user's own projects are listed again
+			io.WriteString(w, `[{"id": 1, "name": "tool", "star_count": 50}, {"id": 5, "name": "platform", "star_count": 30}]`)
+
+		case "/gitlab/api/v4/projects/1/languages":
+			io.WriteString(w, `{"Go": 91.5, "Shell": 8.5}`)
+
+		default:
+			if strings.HasSuffix(r.URL.Path, "/languages") {
+				io.WriteString(w, `{}`)
+				return
+			}
+			t.Errorf("unexpected request %s", r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchProjects(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		opts  forge.Options
+		want  []string
+		err   string
+	}{
+		{name: "stars, every page", want: []string{"tool", "infra"}},
+		{name: "token", token: "glpat-secret", want: []string{"tool", "infra"}},
+		{name: "tag list as topics", opts: forge.Options{Include: []string{"topic:terraform"}}, want: []string{"infra"}},
+		{name: "contributed projects", opts: forge.Options{IncludeOrgs: true}, want: []string{"tool", "platform", "infra"}},
+		{name: "commits", opts: forge.Options{Rank: forge.RankCommits}, err: "ranking 'commits' is not supported for GitLab (use stars or recent)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newServer(t, tt.token)
+
+			// A trailing slash on the instance URL is ignored
+			projects, err := New(srv.URL+"/gitlab/", tt.token).FetchProjects("jane", tt.opts)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FetchProjects: %v", err)
+			}
+
+			var names []string
+			for _, proj := range projects {
+				names = append(names, proj.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("projects = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchProjectsLanguages(t *testing.T) {
+	srv := newServer(t, "")
+
+	projects, err := New(srv.URL+"/gitlab", "").FetchProjects("jane", forge.Options{Count: 1})
+	if err != nil {
+		t.Fatalf("FetchProjects: %v", err)
+	}
+	// Percentages are turned into comparable shares
+	if want := map[string]int{"Go": 9150, "Shell": 850}; !reflect.DeepEqual(projects[0].Languages, want) {
+		t.Errorf("languages = %v, want %v", projects[0].Languages, want)
+	}
+	if want := []string{"Go", "Shell", "cli"}; !reflect.DeepEqual(projects[0].Technologies, want) {
+		t.Errorf("technologies = %v, want %v", projects[0].Technologies, want)
+	}
+}
+
+func TestFetchProjectsUnknownUser(t *testing.T) {
+	srv := newServer(t, "")
+
+	_, err := New(srv.URL+"/gitlab", "").FetchProjects("nobody", forge.Options{})
+	if want := "GitLab user 'nobody' not found"; err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %q", err, want)
+	}
+}
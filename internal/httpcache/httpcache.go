@@ -0,0 +1,336 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DebugMode enables debug output
+var DebugMode bool
+
+// DefaultTTL is how long responses are served without revalidation when no
+// host-specific TTL is configured
+const DefaultTTL = time.Hour
+
+// ErrOffline is returned in offline mode for requests that are not cached
+var ErrOffline = errors.New("not available offline: no cached response")
+
+// keyHeaders are the request headers a cached response depends on, so that
+// anonymous and authenticated responses are cached separately
+var keyHeaders = []string{"Accept", "Authorization", "Cookie", "Private-Token"}
+
+// privateHeaders are the response headers never written to disk: session
+// cookies and authentication challenges are of no use to a cached response
+var privateHeaders = []string{
+	"Set-Cookie", "Set-Cookie2", "Authorization", "Proxy-Authorization",
+	"WWW-Authenticate", "Proxy-Authenticate", "Authentication-Info", "Proxy-Authentication-Info",
+}
+
+// Transport is an http.RoundTripper caching successful GET responses on
+// disk. Cached responses are served while fresh, revalidated with their ETag
+// or Last-Modified date once stale, and served regardless of age offline.
+// Requests sent with "Cache-Control: no-store" bypass the cache entirely.
+type Transport struct {
+	Base    http.RoundTripper        // Underlying transport (default: http.DefaultTransport)
+	Dir     string                   // Cache directory
+	TTL     time.Duration            // Freshness lifetime (default: DefaultTTL)
+	TTLs    map[string]time.Duration // Freshness lifetime per host, overriding TTL
+	Offline bool                     // Never use the network
+}
+
+// Entry is a cached response
+type Entry struct {
+	URL      string      `json:"url"`
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"storedAt"`
+}
+
+// DefaultDir returns the cache directory: $XDG_CACHE_HOME/resumectl/http on
+// Linux, and the platform equivalent elsewhere
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "resumectl", "http"), nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests revalidated by the caller itself, or whose response must not
+	// be stored, are passed through
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" || noStore(req) {
+		if t.Offline {
+			return nil, ErrOffline
+		}
+		return t.base().RoundTrip(req)
+	}
+
+	key := Key(req)
+	cached, err := t.load(key)
+	if err != nil && DebugMode {
+		fmt.Printf("[DEBUG] Ignoring unreadable cache entry for %s: %v\n", req.URL, err)
+	}
+
+	if cached != nil && (t.Offline || t.Fresh(cached)) {
+		if DebugMode {
+			fmt.Printf("[DEBUG] Cache hit: %s (stored %s ago)\n", req.URL, time.Since(cached.StoredAt).Round(time.Second))
+		}
+		return cached.response(req), nil
+	}
+	if t.Offline {
+		return nil, ErrOffline
+	}
+
+	outgoing := req
+	if cached != nil {
+		outgoing = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			outgoing.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); modified != "" {
+			outgoing.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := t.base().RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		resp.Body.Close()
+		if DebugMode {
+			fmt.Printf("[DEBUG] Cache revalidated: %s\n", req.URL)
+		}
+		cached.StoredAt = time.Now()
+		t.store(key, cached)
+		return cached.response(req), nil
+
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		t.store(key, &Entry{
+			URL:      req.URL.String(),
+			Status:   resp.StatusCode,
+			Header:   resp.Header,
+			Body:     body,
+			StoredAt: time.Now(),
+		})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+
+	return resp, nil
+}
+
+// noStore reports whether a request asks for its response not to be cached
+func noStore(req *http.Request) bool {
+	for _, directive := range strings.Split(req.Header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+			return true
+		}
+	}
+	return false
+}
+
+// storedHeader returns a copy of a response header without the private
+// headers
+func storedHeader(header http.Header) http.Header {
+	stored := header.Clone()
+	for _, name := range privateHeaders {
+		stored.Del(name)
+	}
+	return stored
+}
+
+// Fresh reports whether an entry can be served without revalidation
+func (t *Transport) Fresh(e *Entry) bool {
+	return time.Since(e.StoredAt) < t.ttl(e.URL)
+}
+
+func (t *Transport) ttl(rawURL string) time.Duration {
+	host := rawURL
+	if i := strings.Index(host, "://"); i != -1 {
+		host = host[i+3:]
+	}
+	host, _, _ = strings.Cut(host, "/")
+	if ttl, ok := t.TTLs[host]; ok {
+		return ttl
+	}
+	if t.TTL > 0 {
+		return t.TTL
+	}
+	return DefaultTTL
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// Key returns the cache key of a request: a hash of its URL and of the
+// headers the response depends on. Credentials are never stored in clear.
+func Key(req *http.Request) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", req.Method, req.URL)
+	for _, name := range keyHeaders {
+		fmt.Fprintf(h, "%s: %s\n", name, req.Header.Get(name))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (t *Transport) load(key string) (*Entry, error) {
+	data, err := os.ReadFile(filepath.Join(t.Dir, key+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// store writes an entry atomically, without its private headers. Cached
+// responses may hold private profile data, so they are only readable by the
+// user.
+func (t *Transport) store(key string, e *Entry) {
+	e.Header = storedHeader(e.Header)
+	if err := write(t.Dir, key, e); err != nil && DebugMode {
+		fmt.Printf("[DEBUG] Could not cache %s: %v\n", e.URL, err)
+	}
+}
+
+func write(dir, key string, e *Entry) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, key+".json"))
+}
+
+// response rebuilds an HTTP response from a cached entry
+func (e *Entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// List returns the entries of a cache directory, most recent first
+func List(dir string) ([]Entry, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var e Entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].StoredAt.After(entries[j].StoredAt)
+	})
+	return entries, nil
+}
+
+// Clear removes the entries of a cache directory for which remove returns
+// true (all of them when remove is nil) and returns how many were removed
+func Clear(dir string, remove func(Entry) bool) (int, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, file := range files {
+		if remove != nil {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return removed, err
+			}
+			var e Entry
+			if err := json.Unmarshal(data, &e); err == nil && !remove(e) {
+				continue
+			}
+		}
+		if err := os.Remove(file); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package httpcache
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingServer answers every request with an ETag and counts the requests
+// and revalidations it receives
+type countingServer struct {
+	*httptest.Server
+	requests      int
+	revalidations int
+}
+
+func newCountingServer(t *testing.T) *countingServer {
+	t.Helper()
+
+	s := &countingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			s.revalidations++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		io.WriteString(w, "body of "+r.URL.Path+" for "+r.Header.Get("Authorization"))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// get performs a GET through the transport and returns the response body
+func get(t *testing.T, transport *Transport, url string, header map[string]string) (string, *http.Response) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body), resp
+}
+
+func TestKeyHeaders(t *testing.T) {
+	newRequest := func(header map[string]string) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/users/x", nil)
+		for name, value := range header {
+			req.Header.Set(name, value)
+		}
+		return req
+	}
+
+	anonymous := Key(newRequest(nil))
+	tests := []struct {
+		name   string
+		header map[string]string
+		same   bool
+	}{
+		{name: "no headers", same: true},
+		{name: "unrelated header", header: map[string]string{"User-Agent": "resumectl"}, same: true},
+		{name: "accept", header: map[string]string{"Accept": "application/json"}},
+		{name: "authorization", header: map[string]string{"Authorization": "Bearer t"}},
+		{name: "cookie", header: map[string]string{"Cookie": "li_at=x"}},
+		{name: "private token", header: map[string]string{"Private-Token": "t"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := Key(newRequest(tt.header))
+			if (key == anonymous) != tt.same {
+				t.Errorf("Key equal to the anonymous key: %v, want %v", key == anonymous, tt.same)
+			}
+			for _, value := range tt.header {
+				if strings.Contains(key, value) {
+					t.Errorf("Key %q contains the header value %q", key, value)
+				}
+			}
+		})
+	}
+}
+
+func TestTTL(t *testing.T) {
+	transport := &Transport{
+		TTL:  2 * time.Hour,
+		TTLs: map[string]time.Duration{"www.linkedin.com": 24 * time.Hour},
+	}
+
+	tests := []struct {
+		url  string
+		want time.Duration
+	}{
+		{url: "https://www.linkedin.com/in/jane/", want: 24 * time.Hour},
+		{url: "https://api.github.com/users/jane", want: 2 * time.Hour},
+		{url: "https://www.linkedin.com", want: 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := transport.ttl(tt.url); got != tt.want {
+			t.Errorf("ttl(%q) = %s, want %s", tt.url, got, tt.want)
+		}
+	}
+
+	if got := (&Transport{}).ttl("https://example.com/"); got != DefaultTTL {
+		t.Errorf("ttl without configuration = %s, want %s", got, DefaultTTL)
+	}
+
+	entry := &Entry{URL: "https://api.github.com/users/jane", StoredAt: time.Now().Add(-3 * time.Hour)}
+	if transport.Fresh(entry) {
+		t.Error("entry older than its TTL reported fresh")
+	}
+	entry.StoredAt = time.Now().Add(-time.Hour)
+	if !transport.Fresh(entry) {
+		t.Error("entry younger than its TTL reported stale")
+	}
+}
+
+func TestFreshResponseServedFromCache(t *testing.T) {
+	srv := newCountingServer(t)
+	transport := &Transport{Dir: t.TempDir()}
+
+	first, _ := get(t, transport, srv.URL+"/a", nil)
+	second, _ := get(t, transport, srv.URL+"/a", nil)
+	if first != second {
+		t.Errorf("cached body = %q, want %q", second, first)
+	}
+	if srv.requests != 1 {
+		t.Errorf("server received %d requests, want 1", srv.requests)
+	}
+
+	// Responses depending on credentials are cached separately
+	authenticated, _ := get(t, transport, srv.URL+"/a", map[string]string{"Authorization": "Bearer t"})
+	if authenticated == first || srv.requests != 2 {
+		t.Errorf("authenticated request served the anonymous response %q", authenticated)
+	}
+}
+
+func TestStaleResponseRevalidated(t *testing.T) {
+	srv := newCountingServer(t)
+	transport := &Transport{Dir: t.TempDir(), TTL: time.Nanosecond}
+
+	first, _ := get(t, transport, srv.URL+"/a", nil)
+	time.Sleep(time.Millisecond)
+	second, resp := get(t, transport, srv.URL+"/a", nil)
+
+	if srv.revalidations != 1 {
+		t.Errorf("server received %d revalidations, want 1", srv.revalidations)
+	}
+	if resp.StatusCode != http.StatusOK || second != first {
+		t.Errorf("revalidated response = %d %q, want 200 %q", resp.StatusCode, second, first)
+	}
+}
+
+func TestPrivateHeadersNotStored(t *testing.T) {
+	srv := newCountingServer(t)
+	dir := t.TempDir()
+	transport := &Transport{Dir: dir}
+
+	_, resp := get(t, transport, srv.URL+"/a", nil)
+	if resp.Header.Get("Set-Cookie") == "" {
+		t.Error("Set-Cookie removed from the live response")
+	}
+
+	_, cached := get(t, transport, srv.URL+"/a", nil)
+	for _, name := range []string{"Set-Cookie", "WWW-Authenticate"} {
+		if cached.Header.Get(name) != "" {
+			t.Errorf("cached response has a %s header", name)
+		}
+	}
+	if cached.Header.Get("ETag") == "" {
+		t.Error("cached response lost its ETag")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		data, err := os.ReadFile(dir + "/" + entry.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "secret") {
+			t.Errorf("%s contains the session cookie", entry.Name())
+		}
+	}
+}
+
+func TestNoStoreBypassesCache(t *testing.T) {
+	srv := newCountingServer(t)
+	dir := t.TempDir()
+	transport := &Transport{Dir: dir}
+
+	noStore := map[string]string{"Cache-Control": "no-store"}
+	get(t, transport, srv.URL+"/a", noStore)
+	_, resp := get(t, transport, srv.URL+"/a", noStore)
+
+	if srv.requests != 2 {
+		t.Errorf("server received %d requests, want 2", srv.requests)
+	}
+	if resp.Header.Get("Set-Cookie") == "" {
+		t.Error("no-store response lost its Set-Cookie header")
+	}
+	if entries, _ := List(dir); len(entries) != 0 {
+		t.Errorf("no-store responses cached: %d entries", len(entries))
+	}
+}
+
+func TestOffline(t *testing.T) {
+	srv := newCountingServer(t)
+	dir := t.TempDir()
+
+	online := &Transport{Dir: dir}
+	want, _ := get(t, online, srv.URL+"/a", nil)
+
+	offline := &Transport{Dir: dir, TTL: time.Nanosecond, Offline: true}
+	time.Sleep(time.Millisecond)
+	if got, _ := get(t, offline, srv.URL+"/a", nil); got != want {
+		t.Errorf("offline body = %q, want %q", got, want)
+	}
+	if srv.requests != 1 {
+		t.Errorf("offline mode reached the server: %d requests", srv.requests)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/missing", nil)
+	if _, err := offline.RoundTrip(req); !errors.Is(err, ErrOffline) {
+		t.Errorf("uncached request offline: got %v, want ErrOffline", err)
+	}
+}
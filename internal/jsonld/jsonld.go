@@ -0,0 +1,215 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package jsonld describes a CV with schema.org vocabulary, embedded in
+// HTML pages as JSON-LD for search engines and crawlers.
+package jsonld
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"resumectl/internal/models"
+)
+
+// Object is a JSON-LD node
+type Object map[string]any
+
+// Person describes the owner of the CV with their positions
+// (OrganizationRole), schools, degrees and certifications
+// (EducationalOccupationalCredential), skills and languages. url is the
+// absolute address of the CV page, if known; relative photo paths are
+// resolved against it.
+func Person(cv *models.CV, url string) Object {
+	p := cv.Personal
+	person := Object{
+		"@context": "https://schema.org",
+		"@type":    "Person",
+		"name":     p.FullName(),
+	}
+	set(person, "givenName", p.FirstName)
+	set(person, "familyName", p.LastName)
+	set(person, "jobTitle", p.Title)
+	set(person, "email", mailto(p.Email))
+	set(person, "telephone", p.Phone)
+	set(person, "description", strings.Join(strings.Fields(cv.Summary), " "))
+	set(person, "url", url)
+	if p.Photo != "" {
+		set(person, "image", resolve(url, p.Photo))
+	}
+	if p.Location != "" {
+		person["address"] = Object{"@type": "PostalAddress", "addressLocality": p.Location}
+	}
+
+	var sameAs []string
+	for _, profile := range []string{p.LinkedIn, p.GitHub, p.Website} {
+		if profile != "" {
+			sameAs = append(sameAs, absoluteURL(profile))
+		}
+	}
+	if len(sameAs) > 0 {
+		person["sameAs"] = sameAs
+	}
+
+	// Positions use the schema.org role pattern: the role wraps the
+	// organization and carries the title and the dates
+	var roles []Object
+	for _, exp := range cv.Experience {
+		if exp.Company == "" {
+			continue
+		}
+		role := Object{
+			"@type":    "OrganizationRole",
+			"worksFor": Object{"@type": "Organization", "name": exp.Company},
+		}
+		set(role, "roleName", exp.Position)
+		set(role, "startDate", ISODate(exp.StartDate))
+		if !models.IsCurrent(exp.EndDate) {
+			set(role, "endDate", ISODate(exp.EndDate))
+		}
+		set(role, "description", strings.Join(strings.Fields(exp.Description), " "))
+		if exp.Location != "" {
+			role["location"] = Object{"@type": "Place", "name": exp.Location}
+		}
+		roles = append(roles, role)
+	}
+	if len(roles) > 0 {
+		person["worksFor"] = roles
+	}
+
+	var credentials []Object
+	for _, edu := range cv.Education {
+		credential := Object{
+			"@type":              "EducationalOccupationalCredential",
+			"credentialCategory": "degree",
+			"name":               joinNonEmpty(" - ", edu.Degree, edu.Field),
+		}
+		if edu.Institution != "" {
+			credential["recognizedBy"] = Object{"@type": "EducationalOrganization", "name": edu.Institution}
+		}
+		if !models.IsCurrent(edu.EndDate) {
+			set(credential, "dateCreated", ISODate(edu.EndDate))
+		}
+		credentials = append(credentials, credential)
+	}
+	for _, cert := range cv.Certifications {
+		credential := Object{
+			"@type":              "EducationalOccupationalCredential",
+			"credentialCategory": "certification",
+			"name":               cert.Name,
+		}
+		if cert.Issuer != "" {
+			credential["recognizedBy"] = Object{"@type": "Organization", "name": cert.Issuer}
+		}
+		set(credential, "dateCreated", ISODate(cert.Date))
+		credentials = append(credentials, credential)
+	}
+	if len(credentials) > 0 {
+		person["hasCredential"] = credentials
+	}
+
+	var alumniOf []Object
+	for _, edu := range cv.Education {
+		if edu.Institution != "" {
+			alumniOf = append(alumniOf, Object{"@type": "EducationalOrganization", "name": edu.Institution})
+		}
+	}
+	if len(alumniOf) > 0 {
+		person["alumniOf"] = alumniOf
+	}
+
+	var skills []string
+	for _, category := range cv.Skills {
+		skills = append(skills, category.Items...)
+	}
+	if len(skills) > 0 {
+		person["knowsAbout"] = skills
+	}
+
+	var languages []string
+	for _, lang := range cv.Languages {
+		languages = append(languages, lang.Name)
+	}
+	if len(languages) > 0 {
+		person["knowsLanguage"] = languages
+	}
+
+	return person
+}
+
+// Script returns v as a <script type="application/ld+json"> element
+func Script(v any) (string, error) {
+	data, err := json.MarshalIndent(v, "    ", "  ")
+	if err != nil {
+		return "", err
+	}
+	// json.Marshal escapes <, > and &, so the content cannot close the element
+	return `<script type="application/ld+json">` + "\n    " + string(data) + "\n    </script>", nil
+}
+
+// set adds a property unless value is empty
+func set(obj Object, key, value string) {
+	if value != "" {
+		obj[key] = value
+	}
+}
+
+func mailto(email string) string {
+	if email == "" {
+		return ""
+	}
+	return "mailto:" + email
+}
+
+// absoluteURL adds https:// to the profile URLs written without a scheme
+func absoluteURL(u string) string {
+	if strings.Contains(u, "://") {
+		return u
+	}
+	return "https://" + u
+}
+
+// resolve makes a relative path absolute against the page URL when known
+func resolve(pageURL, path string) string {
+	if strings.Contains(path, "://") || pageURL == "" {
+		return path
+	}
+	return strings.TrimSuffix(pageURL, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// joinNonEmpty joins the non-empty parts with sep
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+var isoDatePattern = regexp.MustCompile(`^\d{4}(-\d{2}(-\d{2})?)?$`)
+
+// ISODate returns a CV date in the ISO 8601 form schema.org expects: YYYY,
+// YYYY-MM or YYYY-MM-DD, MM/YYYY dates being converted. Dates in another
+// form, such as "Sept 2020", give an empty string.
+func ISODate(date string) string {
+	date = models.NormalizeDate(date)
+	if !isoDatePattern.MatchString(date) {
+		return ""
+	}
+	return date
+}
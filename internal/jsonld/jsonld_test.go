@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jsonld
+
+import (
+	"testing"
+
+	"resumectl/internal/models"
+)
+
+func TestISODate(t *testing.T) {
+	tests := map[string]string{
+		"2020":       "2020",
+		"2020-09":    "2020-09",
+		"2020-09-15": "2020-09-15",
+		"09/2020":    "2020-09",
+		"9/2020":     "2020-09",
+		" 2020 ":     "2020",
+		"Sept 2020":  "",
+		"present":    "",
+		"":           "",
+	}
+	for date, want := range tests {
+		if got := ISODate(date); got != want {
+			t.Errorf("ISODate(%q) = %q, want %q", date, got, want)
+		}
+	}
+}
+
+func TestPersonDates(t *testing.T) {
+	cv := &models.CV{
+		Experience: []models.Experience{
+			{Company: "Acme", StartDate: "09/2020", EndDate: "Present"},
+			{Company: "Initech", StartDate: "2018-01", EndDate: "08/2020"},
+		},
+		Education: []models.Education{{Institution: "MIT", Degree: "MSc", EndDate: "06/2017"}},
+	}
+
+	roles := Person(cv, "")["worksFor"].([]Object)
+	if got := roles[0]["startDate"]; got != "2020-09" {
+		t.Errorf("startDate = %v, want 2020-09", got)
+	}
+	if _, ok := roles[0]["endDate"]; ok {
+		t.Errorf("ongoing position has an endDate: %v", roles[0]["endDate"])
+	}
+	if got := roles[1]["endDate"]; got != "2020-08" {
+		t.Errorf("endDate = %v, want 2020-08", got)
+	}
+
+	credentials := Person(cv, "")["hasCredential"].([]Object)
+	if got := credentials[0]["dateCreated"]; got != "2017-06" {
+		t.Errorf("dateCreated = %v, want 2017-06", got)
+	}
+}
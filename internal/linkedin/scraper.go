@@ -25,12 +25,21 @@ import (
 	"strings"
 	"time"
 
+	"resumectl/internal/credentials"
 	"resumectl/internal/models"
 )
 
 // DebugMode enables debug logs
 var DebugMode bool
 
+// BaseURL is the LinkedIn origin used for profile pages and the Voyager API
+var BaseURL = "https://www.linkedin.com"
+
+// HTTPClient is the client used for all LinkedIn requests
+var HTTPClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
 // LinkedInProfile represents data extracted from a LinkedIn profile
 type LinkedInProfile struct {
 	FirstName      string
@@ -130,13 +139,11 @@ func FetchProfile(username string) (*LinkedInProfile, error) {
 // FetchProfileWithAuth retrieves LinkedIn profile data with optional authentication
 // If sessionCookie is provided (li_at cookie), all data will be accessible via Voyager API
 func FetchProfileWithAuth(username string, sessionCookie string) (*LinkedInProfile, error) {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+	client := HTTPClient
 
 	// If authenticated, use Voyager API to retrieve complete data
 	if sessionCookie != "" {
-		profile, err := fetchViaVoyagerAPI(client, username, sessionCookie)
+		profile, err := fetchViaVoyagerAPI(client, BaseURL, username, sessionCookie)
 		if err == nil && profile.FirstName != "" {
 			return profile, nil
 		}
@@ -144,7 +151,7 @@ func FetchProfileWithAuth(username string, sessionCookie string) (*LinkedInProfi
 	}
 
 	// Fallback: retrieve via public HTML page
-	profileURL := fmt.Sprintf("https://www.linkedin.com/in/%s/", username)
+	profileURL := fmt.Sprintf("%s/in/%s/", BaseURL, username)
 
 	req, err := http.NewRequest("GET", profileURL, nil)
 	if err != nil {
@@ -178,11 +185,13 @@ func FetchProfileWithAuth(username string, sessionCookie string) (*LinkedInProfi
 }
 
 // fetchViaVoyagerAPI retrieves complete data via LinkedIn's internal API
-func fetchViaVoyagerAPI(client *http.Client, username string, sessionCookie string) (*LinkedInProfile, error) {
+func fetchViaVoyagerAPI(client *http.Client, baseURL string, username string, sessionCookie string) (*LinkedInProfile, error) {
 	profile := &LinkedInProfile{}
 
-	// First, fetch the page to get JSESSIONID (CSRF token)
-	pageURL := fmt.Sprintf("https://www.linkedin.com/in/%s/", username)
+	// First, fetch the page to get JSESSIONID (CSRF token). The cookie is
+	// only sent by LinkedIn itself: this request and the API calls using
+	// the session must never be served from the cache.
+	pageURL := fmt.Sprintf("%s/in/%s/", baseURL, username)
 	req, err := http.NewRequest("GET", pageURL, nil)
 	if err != nil {
 		return nil, err
@@ -190,6 +199,7 @@ func fetchViaVoyagerAPI(client *http.Client, username string, sessionCookie stri
 
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
 	req.Header.Set("Cookie", "li_at="+sessionCookie)
+	req.Header.Set("Cache-Control", "no-store")
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -231,11 +241,11 @@ func fetchViaVoyagerAPI(client *http.Client, username string, sessionCookie stri
 	}
 
 	if DebugMode {
-		fmt.Printf("[DEBUG] JSESSIONID found: %s\n", jsessionid)
+		fmt.Printf("[DEBUG] JSESSIONID found: %s\n", credentials.Redact(jsessionid))
 	}
 
 	// Call Voyager API to retrieve complete profile
-	apiURL := fmt.Sprintf("https://www.linkedin.com/voyager/api/identity/dash/profiles?q=memberIdentity&memberIdentity=%s&decorationId=com.linkedin.voyager.dash.deco.identity.profile.FullProfileWithEntities-93", username)
+	apiURL := fmt.Sprintf("%s/voyager/api/identity/dash/profiles?q=memberIdentity&memberIdentity=%s&decorationId=com.linkedin.voyager.dash.deco.identity.profile.FullProfileWithEntities-93", baseURL, username)
 
 	req, err = http.NewRequest("GET", apiURL, nil)
 	if err != nil {
@@ -246,6 +256,7 @@ func fetchViaVoyagerAPI(client *http.Client, username string, sessionCookie stri
 	req.Header.Set("Accept", "application/vnd.linkedin.normalized+json+2.1")
 	req.Header.Set("Cookie", fmt.Sprintf("li_at=%s; JSESSIONID=\"%s\"", sessionCookie, jsessionid))
 	req.Header.Set("csrf-token", jsessionid)
+	req.Header.Set("Cache-Control", "no-store")
 	req.Header.Set("x-li-lang", "fr_FR")
 	req.Header.Set("x-restli-protocol-version", "2.0.0")
 	req.Header.Set("x-li-track", `{"clientVersion":"1.13.8677","mpVersion":"1.13.8677","osName":"web","timezoneOffset":1,"timezone":"Europe/Paris","deviceFormFactor":"DESKTOP","mpName":"voyager-web","displayDensity":1,"displayWidth":1920,"displayHeight":1080}`)
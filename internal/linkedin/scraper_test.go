@@ -0,0 +1,205 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package linkedin
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"resumectl/internal/httpcache"
+
+	"gopkg.in/yaml.v3"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata/golden")
+
+const testSessionCookie = "AQEDAxTestCookie"
+const testJSESSIONID = "ajax:0123456789"
+
+// fixtureServer serves saved LinkedIn pages from testdata/pages and Voyager
+// responses from testdata/voyager, mimicking the real endpoints
+func fixtureServer(t *testing.T, voyager bool) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/in/", func(w http.ResponseWriter, r *http.Request) {
+		username := strings.Trim(strings.TrimPrefix(r.URL.Path, "/in/"), "/")
+		page, err := os.ReadFile(filepath.Join("testdata", "pages", username+".html"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		if cookie, err := r.Cookie("li_at"); err == nil && cookie.Value == testSessionCookie {
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: `"` + testJSESSIONID + `"`})
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+	mux.HandleFunc("/voyager/api/identity/dash/profiles", func(w http.ResponseWriter, r *http.Request) {
+		if !voyager {
+			http.Error(w, `{"status":403}`, http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("csrf-token") != testJSESSIONID {
+			http.Error(w, `{"status":403}`, http.StatusForbidden)
+			return
+		}
+		if cookie, err := r.Cookie("li_at"); err != nil || cookie.Value != testSessionCookie {
+			http.Error(w, `{"status":401}`, http.StatusUnauthorized)
+			return
+		}
+		username := r.URL.Query().Get("memberIdentity")
+		data, err := os.ReadFile(filepath.Join("testdata", "voyager", username+".json"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.linkedin.normalized+json+2.1")
+		w.Write(data)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// useServer points the package at srv for the duration of the test
+func useServer(t *testing.T, srv *httptest.Server) {
+	t.Helper()
+
+	prevURL, prevClient := BaseURL, HTTPClient
+	BaseURL, HTTPClient = srv.URL, srv.Client()
+	t.Cleanup(func() {
+		BaseURL, HTTPClient = prevURL, prevClient
+	})
+}
+
+// assertGolden compares got with testdata/golden/name, rewriting it with -update
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", "golden", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("writing golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", name, got, want)
+	}
+}
+
+func TestFetchProfileGolden(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		cookie   string
+		voyager  bool
+	}{
+		{name: "public-jsonld", username: "jane-doe"},
+		{name: "public-meta-only", username: "meta-only"},
+		{name: "voyager-api", username: "john-smith", cookie: testSessionCookie, voyager: true},
+		{name: "voyager-fallback-page", username: "john-smith", cookie: testSessionCookie},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useServer(t, fixtureServer(t, tt.voyager))
+
+			profile, err := FetchProfileWithAuth(tt.username, tt.cookie)
+			if err != nil {
+				t.Fatalf("FetchProfileWithAuth: %v", err)
+			}
+
+			profileJSON, err := json.MarshalIndent(profile, "", "  ")
+			if err != nil {
+				t.Fatalf("marshalling profile: %v", err)
+			}
+			assertGolden(t, tt.name+".profile.json", append(profileJSON, '\n'))
+
+			cvYAML, err := yaml.Marshal(profile.ToCV("https://www.linkedin.com/in/" + tt.username + "/"))
+			if err != nil {
+				t.Fatalf("marshalling CV: %v", err)
+			}
+			assertGolden(t, tt.name+".cv.yaml", cvYAML)
+		})
+	}
+}
+
+func TestFetchProfileThroughCache(t *testing.T) {
+	srv := fixtureServer(t, true)
+	useServer(t, srv)
+	HTTPClient = &http.Client{Transport: &httpcache.Transport{Base: srv.Client().Transport, Dir: t.TempDir()}}
+
+	// The second fetch must still get the session cookie and reach the
+	// Voyager API instead of a cached page without it
+	var profiles [2][]byte
+	for i := range profiles {
+		profile, err := FetchProfileWithAuth("john-smith", testSessionCookie)
+		if err != nil {
+			t.Fatalf("FetchProfileWithAuth (fetch %d): %v", i+1, err)
+		}
+		if profiles[i], err = json.MarshalIndent(profile, "", "  "); err != nil {
+			t.Fatalf("marshalling profile: %v", err)
+		}
+	}
+
+	assertGolden(t, "voyager-api.profile.json", append(profiles[0], '\n'))
+	if !bytes.Equal(profiles[0], profiles[1]) {
+		t.Errorf("cached fetch differs from the first one\n--- first ---\n%s\n--- second ---\n%s", profiles[0], profiles[1])
+	}
+}
+
+func TestFetchProfileNotFound(t *testing.T) {
+	useServer(t, fixtureServer(t, false))
+
+	if _, err := FetchProfile("does-not-exist"); err == nil {
+		t.Fatal("expected an error for a missing profile")
+	}
+}
+
+func TestExtractUsernameFromURL(t *testing.T) {
+	tests := map[string]string{
+		"johndoe":                                 "johndoe",
+		"https://www.linkedin.com/in/johndoe/":    "johndoe",
+		"linkedin.com/in/john-doe-42":             "john-doe-42",
+		"https://fr.linkedin.com/in/jane?trk=abc": "jane",
+	}
+
+	for input, want := range tests {
+		got, err := ExtractUsernameFromURL(input)
+		if err != nil {
+			t.Errorf("ExtractUsernameFromURL(%q): %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ExtractUsernameFromURL(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
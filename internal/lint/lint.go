@@ -0,0 +1,279 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package lint checks the content quality of a CV beyond schema validity:
+// weak wording, unquantified results, date consistency, employment gaps,
+// duplicated skills and placeholders left from the init template.
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"resumectl/internal/models"
+	"resumectl/internal/spell"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is the level at which a rule reports its findings
+type Severity int
+
+// Severities, from the least to the most severe. Off disables a rule.
+const (
+	Off Severity = iota
+	Info
+	Warning
+	Error
+)
+
+var severityNames = []string{"off", "info", "warning", "error"}
+
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// ParseSeverity parses off, info, warning or error
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if strings.EqualFold(name, n) {
+			return Severity(i), nil
+		}
+	}
+	return Off, fmt.Errorf("unknown severity %q (use %s)", name, strings.Join(severityNames, ", "))
+}
+
+// UnmarshalYAML reads a severity by name
+func (s *Severity) UnmarshalYAML(node *yaml.Node) error {
+	severity, err := ParseSeverity(node.Value)
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
+
+// Finding is a problem reported by a rule
+type Finding struct {
+	Rule     string
+	Severity Severity
+	Path     string // YAML path of the value, e.g. experience[0].highlights[2]
+	Line     int    // Line of the value in the YAML file, 0 if unknown
+	Message  string
+	Word     string // Word of the value the finding is about, if any
+}
+
+// Rule is a content check
+type Rule struct {
+	Name        string
+	Description string
+	Severity    Severity // Default severity
+	Max         int      // Default limit, for the rules that have one
+	check       func(cv *models.CV, opts options) []Finding
+}
+
+// options are the parameters of a rule check
+type options struct {
+	max     int
+	speller *spell.Checker // Set with lint --spell
+}
+
+// Rules lists the available rules
+var Rules = []Rule{
+	{"weak-verb", "Highlights starting with a weak verb (\"responsible for\", \"helped\")", Warning, 0, checkWeakVerbs},
+	{"highlight-length", "Highlights longer than max characters", Warning, 160, checkHighlightLength},
+	{"quantified-results", "Experiences without any figure in their highlights", Info, 0, checkQuantified},
+	{"date-format", "Experience dates that are not dates or do not share one format (YYYY-MM, YYYY, MM/YYYY)", Warning, 0, checkDateFormats},
+	{"employment-overlap", "Experiences overlapping each other", Info, 0, checkOverlaps},
+	{"employment-gap", "Gaps of more than max months between experiences", Info, 6, checkGaps},
+	{"duplicate-skill", "Skills listed in several categories", Warning, 0, checkDuplicateSkills},
+	{"summary-length", "Summary longer than max characters", Warning, 600, checkSummaryLength},
+	{"placeholder", "Values left from the resumectl init template", Error, 0, checkPlaceholders},
+	{"spelling", "Words missing from the dictionaries (with --spell)", Warning, 0, checkSpelling},
+	{"repeated-word", "Words written twice in a row (with --spell)", Warning, 0, checkRepeatedWords},
+}
+
+// RuleConfig overrides the default severity and limit of a rule
+type RuleConfig struct {
+	Severity *Severity `yaml:"severity"`
+	Max      int       `yaml:"max"`
+}
+
+// Config configures the rules, by name
+type Config struct {
+	Rules map[string]RuleConfig `yaml:"rules"`
+}
+
+// LoadConfig reads a lint configuration file:
+//
+//	rules:
+//	  weak-verb:
+//	    severity: off
+//	  highlight-length:
+//	    severity: error
+//	    max: 120
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for name := range config.Rules {
+		if findRule(name) == nil {
+			return nil, fmt.Errorf("%s: unknown rule %q", path, name)
+		}
+	}
+	return &config, nil
+}
+
+func findRule(name string) *Rule {
+	for i := range Rules {
+		if Rules[i].Name == name {
+			return &Rules[i]
+		}
+	}
+	return nil
+}
+
+// Run checks cv with the enabled rules and returns the findings in the
+// order of the CV, most severe first for a same value. config may be nil.
+// The spelling rules only run with a speller.
+func Run(cv *models.CV, config *Config, speller *spell.Checker) []Finding {
+	var findings []Finding
+	for _, rule := range Rules {
+		severity, max := rule.Severity, rule.Max
+		if config != nil {
+			if override, ok := config.Rules[rule.Name]; ok {
+				if override.Severity != nil {
+					severity = *override.Severity
+				}
+				if override.Max > 0 {
+					max = override.Max
+				}
+			}
+		}
+		if severity == Off {
+			continue
+		}
+
+		for _, f := range rule.check(cv, options{max: max, speller: speller}) {
+			f.Rule, f.Severity = rule.Name, severity
+			findings = append(findings, f)
+		}
+	}
+
+	order := pathOrder(cv)
+	sort.SliceStable(findings, func(i, j int) bool {
+		if oi, oj := order(findings[i].Path), order(findings[j].Path); oi != oj {
+			return oi < oj
+		}
+		return findings[i].Severity > findings[j].Severity
+	})
+	return findings
+}
+
+// pathOrder returns the rank of a path in the order of the CV fields.
+// Paths of lists and unknown paths rank with their first value.
+func pathOrder(cv *models.CV) func(path string) int {
+	all := texts(cv)
+	return func(path string) int {
+		for i, t := range all {
+			if t.path == path || strings.HasPrefix(t.path, path+".") || strings.HasPrefix(t.path, path+"[") {
+				return i
+			}
+		}
+		return len(all)
+	}
+}
+
+// Locate fills in the line of the findings from the YAML source of the CV
+// and sorts them by line
+func Locate(findings []Finding, data []byte) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return
+	}
+	lines := strings.Split(string(data), "\n")
+	for i := range findings {
+		node := lookup(&root, findings[i].Path)
+		if node == nil {
+			continue
+		}
+		findings[i].Line = node.Line
+		if findings[i].Word != "" && strings.Contains(node.Value, "\n") {
+			findings[i].Line = wordLine(lines, node, findings[i].Word)
+		}
+	}
+
+	// Words of multi-line values may come before the findings of their line
+	sort.SliceStable(findings, func(i, j int) bool {
+		li, lj := findings[i].Line, findings[j].Line
+		return li != 0 && (lj == 0 || li < lj)
+	})
+}
+
+// wordLine returns the line of the first occurrence of word in the
+// multi-line scalar node
+func wordLine(lines []string, node *yaml.Node, word string) int {
+	pattern := regexp.MustCompile(`(^|[^\pL])` + regexp.QuoteMeta(word) + `($|[^\pL])`)
+	end := min(node.Line+strings.Count(node.Value, "\n")+2, len(lines))
+	for i := node.Line - 1; i < end; i++ {
+		if pattern.MatchString(lines[i]) {
+			return i + 1
+		}
+	}
+	return node.Line
+}
+
+// lookup returns the node at a path like experience[0].highlights[2]
+func lookup(node *yaml.Node, path string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool { return r == '.' || r == '[' }) {
+		if index, ok := strings.CutSuffix(part, "]"); ok {
+			i, err := strconv.Atoi(index)
+			if err != nil || node.Kind != yaml.SequenceNode || i >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[i]
+			continue
+		}
+
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var value *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == part {
+				value = node.Content[i+1]
+			}
+		}
+		if value == nil {
+			return nil
+		}
+		node = value
+	}
+	return node
+}
@@ -0,0 +1,359 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package lint
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"resumectl/internal/models"
+	"resumectl/internal/skills"
+)
+
+// weakVerbs are the openings of highlights describing duties rather than
+// achievements
+var weakVerbs = []string{
+	"responsible for", "in charge of", "tasked with", "duties included",
+	"worked on", "worked with", "helped", "assisted", "participated in",
+	"involved in", "handled", "did", "was", "were", "made",
+	"responsable de", "chargé de", "chargée de", "participation à",
+	"participé à", "aide à", "aidé à", "travail sur", "travaillé sur",
+}
+
+func checkWeakVerbs(cv *models.CV, _ options) []Finding {
+	var findings []Finding
+	for i, exp := range cv.Experience {
+		for j, highlight := range exp.Highlights {
+			highlight = strings.TrimSpace(highlight)
+			lower := strings.ToLower(highlight)
+			for _, verb := range weakVerbs {
+				if lower == verb || strings.HasPrefix(lower, verb+" ") {
+					// Lowercasing may change the byte length: quote the
+					// verb as written by its rune count
+					written := []rune(highlight)[:min(utf8.RuneCountInString(verb), utf8.RuneCountInString(highlight))]
+					findings = append(findings, Finding{
+						Path:    fmt.Sprintf("experience[%d].highlights[%d]", i, j),
+						Message: fmt.Sprintf("starts with %q: lead with an action verb and the result", string(written)),
+					})
+					break
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func checkHighlightLength(cv *models.CV, opts options) []Finding {
+	var findings []Finding
+	for i, exp := range cv.Experience {
+		for j, highlight := range exp.Highlights {
+			if n := utf8.RuneCountInString(highlight); n > opts.max {
+				findings = append(findings, Finding{
+					Path:    fmt.Sprintf("experience[%d].highlights[%d]", i, j),
+					Message: fmt.Sprintf("%d characters, keep highlights under %d", n, opts.max),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+var digit = regexp.MustCompile(`\d`)
+
+func checkQuantified(cv *models.CV, _ options) []Finding {
+	var findings []Finding
+	for i, exp := range cv.Experience {
+		if len(exp.Highlights) == 0 || digit.MatchString(exp.Description) {
+			continue
+		}
+		if !digit.MatchString(strings.Join(exp.Highlights, " ")) {
+			findings = append(findings, Finding{
+				Path:    fmt.Sprintf("experience[%d].highlights", i),
+				Message: fmt.Sprintf("no quantified result at %s (figures, percentages, team size...)", exp.Company),
+			})
+		}
+	}
+	return findings
+}
+
+// dateFormats are the date formats accepted in experiences, by preference
+var dateFormats = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"YYYY-MM", regexp.MustCompile(`^\d{4}-\d{2}$`)},
+	{"YYYY", regexp.MustCompile(`^\d{4}$`)},
+	{"MM/YYYY", regexp.MustCompile(`^\d{1,2}/\d{4}$`)},
+	{"YYYY/MM", regexp.MustCompile(`^\d{4}/\d{1,2}$`)},
+}
+
+func checkDateFormats(cv *models.CV, _ options) []Finding {
+	type date struct{ path, value, format string }
+	var findings []Finding
+	var dates []date
+	count := make(map[string]int)
+	now := time.Now()
+	for i, exp := range cv.Experience {
+		for _, d := range []date{
+			{path: fmt.Sprintf("experience[%d].startDate", i), value: exp.StartDate},
+			{path: fmt.Sprintf("experience[%d].endDate", i), value: exp.EndDate},
+		} {
+			if _, _, ok := month(d.value, now); !ok {
+				findings = append(findings, Finding{
+					Path:    d.path,
+					Message: fmt.Sprintf("%q is not a date: use YYYY-MM or YYYY", d.value),
+				})
+				continue
+			}
+			for _, format := range dateFormats {
+				if format.pattern.MatchString(strings.TrimSpace(d.value)) {
+					d.format = format.name
+					dates = append(dates, d)
+					count[d.format]++
+					break
+				}
+			}
+		}
+	}
+
+	// Report the dates that do not follow the most used format
+	usual := ""
+	for _, format := range dateFormats {
+		if count[format.name] > count[usual] {
+			usual = format.name
+		}
+	}
+	for _, d := range dates {
+		if d.format != usual {
+			findings = append(findings, Finding{
+				Path:    d.path,
+				Message: fmt.Sprintf("%q does not follow the %s format of the other dates", d.value, usual),
+			})
+		}
+	}
+	return findings
+}
+
+// period is the span of an experience in months since year 0. Dates given
+// as a year only cover the whole year, so each bound is a range.
+type period struct {
+	index                  int
+	startLo, startHi       int
+	endLo, endHi           int
+	company, start, finish string
+}
+
+// month parses YYYY, YYYY-MM or MM/YYYY into the first and last month it may
+// denote. Ongoing dates (empty, present) are the current month.
+func month(date string, now time.Time) (lo, hi int, ok bool) {
+	index := func(t time.Time) int { return t.Year()*12 + int(t.Month()) - 1 }
+	if models.IsCurrent(date) {
+		return index(now), index(now), true
+	}
+	first, last, ok := models.ParseDate(date)
+	if !ok {
+		return 0, 0, false
+	}
+	return index(first), index(last), true
+}
+
+// periods returns the experiences with valid dates, by start date
+func periods(cv *models.CV) []period {
+	now := time.Now()
+	var result []period
+	for i, exp := range cv.Experience {
+		p := period{index: i, company: exp.Company, start: exp.StartDate, finish: exp.EndDate}
+		var ok1, ok2 bool
+		p.startLo, p.startHi, ok1 = month(exp.StartDate, now)
+		p.endLo, p.endHi, ok2 = month(exp.EndDate, now)
+		if exp.StartDate != "" && ok1 && ok2 {
+			result = append(result, p)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].startLo < result[j].startLo })
+	return result
+}
+
+func checkOverlaps(cv *models.CV, _ options) []Finding {
+	var findings []Finding
+	all := periods(cv)
+	for i, p := range all {
+		for _, previous := range all[:i] {
+			// Changing jobs within a month is not an overlap
+			if p.startHi < previous.endLo {
+				findings = append(findings, Finding{
+					Path:    fmt.Sprintf("experience[%d].startDate", p.index),
+					Message: fmt.Sprintf("%s overlaps with %s (until %s)", p.company, previous.company, displayEnd(previous.finish)),
+				})
+				break
+			}
+		}
+	}
+	return findings
+}
+
+func checkGaps(cv *models.CV, opts options) []Finding {
+	var findings []Finding
+	all := periods(cv)
+	for i := 1; i < len(all); i++ {
+		// The latest end of the earlier experiences
+		last := all[0]
+		for _, previous := range all[1:i] {
+			if previous.endHi > last.endHi {
+				last = previous
+			}
+		}
+		if gap := all[i].startLo - last.endHi - 1; gap > opts.max {
+			findings = append(findings, Finding{
+				Path:    fmt.Sprintf("experience[%d].startDate", all[i].index),
+				Message: fmt.Sprintf("%d-month gap after %s (until %s)", gap, last.company, displayEnd(last.finish)),
+			})
+		}
+	}
+	return findings
+}
+
+func displayEnd(date string) string {
+	if date == "" {
+		return "present"
+	}
+	return date
+}
+
+func checkDuplicateSkills(cv *models.CV, _ options) []Finding {
+	var findings []Finding
+	seen := make(map[string]string)
+	for i, category := range cv.Skills {
+		for j, item := range category.Items {
+			key := strings.ToLower(skills.Normalize(item))
+			if first, ok := seen[key]; ok {
+				findings = append(findings, Finding{
+					Path:    fmt.Sprintf("skills[%d].items[%d]", i, j),
+					Message: fmt.Sprintf("%q is already listed in %s", item, first),
+				})
+				continue
+			}
+			seen[key] = category.Category
+		}
+	}
+	return findings
+}
+
+func checkSummaryLength(cv *models.CV, opts options) []Finding {
+	if n := utf8.RuneCountInString(strings.TrimSpace(cv.Summary)); n > opts.max {
+		return []Finding{{
+			Path:    "summary",
+			Message: fmt.Sprintf("%d characters, keep the summary under %d", n, opts.max),
+		}}
+	}
+	return nil
+}
+
+// placeholders are the values of the resumectl init template, lowercased
+var placeholders = map[string]bool{
+	"your professional title":     true,
+	"your.email@example.com":      true,
+	"city, country":               true,
+	"linkedin.com/in/yourprofile": true,
+	"github.com/yourusername":     true,
+	"yourwebsite.com":             true,
+	"company name":                true,
+	"previous company":            true,
+	"senior position":             true,
+	"position title":              true,
+	"university name":             true,
+	"field of study":              true,
+	"certification name":          true,
+	"issuing organization":        true,
+	"project name":                true,
+	"github.com/username/project": true,
+}
+
+var (
+	// "Key achievement or responsibility 1", "Language 2", "Tech 3"...
+	placeholderPattern = regexp.MustCompile(`(?i)^(key achievement or responsibility|language|framework|tool|tech|interest) \d$` +
+		`|^(write a brief professional summary|brief description of|relevant coursework, honors)`)
+	exampleEmail = regexp.MustCompile(`(?i)@example\.(com|org|net)$`)
+	nonDigit     = regexp.MustCompile(`\D`)
+)
+
+// placeholderPhone reports numbers made of zeros after the country code,
+// like +1 000 000 0000 or +33 6 00 00 00 00
+func placeholderPhone(phone string) bool {
+	digits := nonDigit.ReplaceAllString(phone, "")
+	return len(digits) >= 8 && strings.Count(digits, "0") >= len(digits)-3
+}
+
+func checkPlaceholders(cv *models.CV, _ options) []Finding {
+	var findings []Finding
+	for _, t := range texts(cv) {
+		value := strings.TrimSpace(t.value)
+		lower := strings.ToLower(value)
+		if placeholders[lower] || placeholderPattern.MatchString(value) || exampleEmail.MatchString(value) ||
+			t.path == "personal.phone" && placeholderPhone(value) {
+			findings = append(findings, Finding{
+				Path:    t.path,
+				Message: fmt.Sprintf("%q looks like a placeholder from the init template", shorten(value, 50)),
+			})
+		}
+	}
+	return findings
+}
+
+func shorten(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "…"
+}
+
+// text is a string value of the CV with its YAML path
+type text struct {
+	path, value string
+}
+
+// texts returns the non-empty string values of cv, in document order
+func texts(cv *models.CV) []text {
+	var result []text
+	var walk func(v reflect.Value, path string)
+	walk = func(v reflect.Value, path string) {
+		switch v.Kind() {
+		case reflect.String:
+			if v.String() != "" {
+				result = append(result, text{path, v.String()})
+			}
+		case reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+				if path != "" {
+					name = path + "." + name
+				}
+				walk(v.Field(i), name)
+			}
+		}
+	}
+	walk(reflect.ValueOf(*cv), "")
+	return result
+}
@@ -0,0 +1,327 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package lint
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"resumectl/internal/models"
+)
+
+// paths returns the paths of findings, in order
+func paths(findings []Finding) []string {
+	var result []string
+	for _, f := range findings {
+		result = append(result, f.Path)
+	}
+	return result
+}
+
+// experiences builds a CV from start and end date pairs
+func experiences(dates ...[2]string) *models.CV {
+	cv := &models.CV{}
+	for i, d := range dates {
+		cv.Experience = append(cv.Experience, models.Experience{
+			Company:   string(rune('A' + i)),
+			StartDate: d[0],
+			EndDate:   d[1],
+		})
+	}
+	return cv
+}
+
+func TestWeakVerbs(t *testing.T) {
+	cv := &models.CV{Experience: []models.Experience{{Highlights: []string{
+		"Responsible for the billing API",
+		"Led a team of 3 engineers",
+		"helped",
+		"Helpdesk migration to Zendesk",
+		"Participé à la refonte du site",
+		"  Worked on the mobile app",
+		"WOR\u212aED ON billing", // The Kelvin sign lowercases to a one-byte k
+	}}}}
+
+	findings := checkWeakVerbs(cv, options{})
+	want := []string{
+		"experience[0].highlights[0]",
+		"experience[0].highlights[2]",
+		"experience[0].highlights[4]",
+		"experience[0].highlights[5]",
+		"experience[0].highlights[6]",
+	}
+	if got := paths(findings); !reflect.DeepEqual(got, want) {
+		t.Fatalf("paths = %q, want %q", got, want)
+	}
+	for i, verb := range map[int]string{0: "Responsible for", 2: "Participé à", 4: "WOR\u212aED ON"} {
+		if !strings.Contains(findings[i].Message, `"`+verb+`"`) {
+			t.Errorf("message = %q, want it to quote %q as written", findings[i].Message, verb)
+		}
+	}
+}
+
+func TestHighlightLength(t *testing.T) {
+	cv := &models.CV{Experience: []models.Experience{{Highlights: []string{"Short", strings.Repeat("é", 11)}}}}
+
+	got := paths(checkHighlightLength(cv, options{max: 10}))
+	if want := []string{"experience[0].highlights[1]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %q, want %q", got, want)
+	}
+}
+
+func TestQuantified(t *testing.T) {
+	cv := &models.CV{Experience: []models.Experience{
+		{Company: "A", Highlights: []string{"Cut costs by 30%"}},
+		{Company: "B", Highlights: []string{"Improved the build"}},
+		{Company: "C", Description: "Team of 12", Highlights: []string{"Improved the build"}},
+		{Company: "D"},
+	}}
+
+	got := paths(checkQuantified(cv, options{}))
+	if want := []string{"experience[1].highlights"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %q, want %q", got, want)
+	}
+}
+
+func TestMonth(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	oct2026 := 2026*12 + 9
+
+	tests := []struct {
+		date   string
+		lo, hi int
+		ok     bool
+	}{
+		{"2020-09", 2020*12 + 8, 2020*12 + 8, true},
+		{"09/2020", 2020*12 + 8, 2020*12 + 8, true},
+		{"9/2020", 2020*12 + 8, 2020*12 + 8, true},
+		{"2020", 2020 * 12, 2020*12 + 11, true},
+		{"", oct2026, oct2026, true},
+		{"Present", oct2026, oct2026, true},
+		{"présent", oct2026, oct2026, true},
+		{"Spring 2020", 0, 0, false},
+		{"2020-13", 0, 0, false},
+	}
+	for _, tt := range tests {
+		lo, hi, ok := month(tt.date, now)
+		if lo != tt.lo || hi != tt.hi || ok != tt.ok {
+			t.Errorf("month(%q) = %d, %d, %v; want %d, %d, %v", tt.date, lo, hi, ok, tt.lo, tt.hi, tt.ok)
+		}
+	}
+}
+
+func TestDateFormats(t *testing.T) {
+	tests := []struct {
+		name string
+		cv   *models.CV
+		want []string
+	}{
+		{
+			name: "consistent",
+			cv:   experiences([2]string{"2020-01", "present"}, [2]string{"2018-03", "2019-12"}),
+		},
+		{
+			name: "consistent MM/YYYY",
+			cv:   experiences([2]string{"01/2020", ""}, [2]string{"3/2018", "12/2019"}),
+		},
+		{
+			name: "mixed and invalid",
+			cv: experiences(
+				[2]string{"2020-01", "present"},
+				[2]string{"2018-03", "2019-12"},
+				[2]string{"03/2016", "2017"},
+				[2]string{"Spring 2015", "2015-06"},
+			),
+			want: []string{
+				"experience[3].startDate",
+				"experience[2].startDate",
+				"experience[2].endDate",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paths(checkDateFormats(tt.cv, options{})); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paths = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		cv   *models.CV
+		want []string
+	}{
+		{
+			name: "job change within a month",
+			cv:   experiences([2]string{"2020-06", "present"}, [2]string{"2018-01", "2020-06"}),
+		},
+		{
+			name: "overlap",
+			cv: experiences(
+				[2]string{"2020-07", "present"},
+				[2]string{"2018-01", "2020-06"},
+				[2]string{"2019-01", "2019-12"},
+			),
+			want: []string{"experience[2].startDate"},
+		},
+		{
+			name: "year-only dates may not overlap",
+			cv:   experiences([2]string{"2019", "2020"}, [2]string{"2017", "2019"}),
+		},
+		{
+			name: "invalid dates ignored",
+			cv:   experiences([2]string{"2019-01", "present"}, [2]string{"soon", "2020-01"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paths(checkOverlaps(tt.cv, options{})); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paths = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGaps(t *testing.T) {
+	tests := []struct {
+		name string
+		cv   *models.CV
+		want []string
+	}{
+		{
+			name: "short gaps",
+			cv:   experiences([2]string{"2019-03", "present"}, [2]string{"2017-01", "2018-12"}),
+		},
+		{
+			name: "long gap",
+			cv: experiences(
+				[2]string{"2019-03", "present"},
+				[2]string{"2017-01", "2018-12"},
+				[2]string{"2015-01", "2016-01"},
+			),
+			want: []string{"experience[1].startDate"},
+		},
+		{
+			name: "gap measured from the latest end",
+			cv: experiences(
+				[2]string{"2010-01", "2016-12"},
+				[2]string{"2012-01", "2013-01"},
+				[2]string{"2017-03", "present"},
+			),
+		},
+		{
+			name: "year-only dates at their closest",
+			cv:   experiences([2]string{"2010", "2011"}, [2]string{"2012-03", "present"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := checkGaps(tt.cv, options{max: 6})
+			if got := paths(findings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paths = %q, want %q", got, tt.want)
+			}
+			if len(findings) > 0 && findings[0].Message != "11-month gap after C (until 2016-01)" {
+				t.Errorf("message = %q", findings[0].Message)
+			}
+		})
+	}
+}
+
+func TestDuplicateSkills(t *testing.T) {
+	cv := &models.CV{Skills: []models.SkillCategory{
+		{Category: "Languages", Items: []string{"Go", "Python"}},
+		{Category: "Tools", Items: []string{"Docker", "python", "docker"}},
+	}}
+
+	findings := checkDuplicateSkills(cv, options{})
+	want := []string{"skills[1].items[1]", "skills[1].items[2]"}
+	if got := paths(findings); !reflect.DeepEqual(got, want) {
+		t.Fatalf("paths = %q, want %q", got, want)
+	}
+	if !strings.Contains(findings[0].Message, "Languages") {
+		t.Errorf("message = %q, want it to name the first category", findings[0].Message)
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	cv := &models.CV{
+		Personal: models.Personal{
+			FirstName: "Jane",
+			Title:     "Your Professional Title",
+			Email:     "jane@example.com",
+			Phone:     "+33 6 00 00 00 00",
+			Location:  "Paris, France",
+			GitHub:    "github.com/yourusername",
+		},
+		Summary: "Write a brief professional summary highlighting your key skills.",
+		Experience: []models.Experience{{
+			Company:    "Company Name",
+			Highlights: []string{"Key achievement or responsibility 1", "Shipped 3 releases"},
+		}},
+	}
+
+	got := paths(checkPlaceholders(cv, options{}))
+	sort.Strings(got)
+	want := []string{
+		"experience[0].company",
+		"experience[0].highlights[0]",
+		"personal.email",
+		"personal.github",
+		"personal.phone",
+		"personal.title",
+		"summary",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %q, want %q", got, want)
+	}
+
+	cv = &models.CV{Personal: models.Personal{Phone: "+33 6 12 34 56 78", Email: "jane@example.fr"}}
+	if findings := checkPlaceholders(cv, options{}); len(findings) != 0 {
+		t.Errorf("real values reported: %v", findings)
+	}
+}
+
+func TestRunConfig(t *testing.T) {
+	cv := &models.CV{Experience: []models.Experience{{
+		Company:    "Acme",
+		StartDate:  "2020-01",
+		Highlights: []string{"Helped with the release", "Shipped version 2 of the API"},
+	}}}
+
+	off, errorSeverity := Off, Error
+	config := &Config{Rules: map[string]RuleConfig{
+		"weak-verb":        {Severity: &off},
+		"highlight-length": {Severity: &errorSeverity, Max: 25},
+	}}
+
+	var got []string
+	for _, f := range Run(cv, config, nil) {
+		got = append(got, f.Rule+" "+f.Severity.String()+" "+f.Path)
+	}
+	want := []string{"highlight-length error experience[0].highlights[1]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findings = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"resumectl/internal/models"
+	"resumectl/internal/skills"
+)
+
+// proseFields are the last elements of the paths holding prose, as opposed
+// to names, dates and URLs
+var proseFields = map[string]bool{
+	"title": true, "summary": true, "position": true, "description": true,
+	"highlights": true, "degree": true, "field": true, "interests": true,
+}
+
+// prose returns the prose values of cv
+func prose(cv *models.CV) []text {
+	var result []text
+	for _, t := range texts(cv) {
+		field := t.path[strings.LastIndex(t.path, ".")+1:]
+		field, _, _ = strings.Cut(field, "[")
+		if proseFields[field] {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// word is a word of a prose value
+type word struct {
+	text   string
+	follow bool // Follows the previous word without punctuation in between
+}
+
+// jargon lists common technical words missing from general dictionaries
+var jargon = map[string]bool{
+	"backend": true, "frontend": true, "fullstack": true, "devops": true,
+	"microservice": true, "microservices": true, "middleware": true,
+	"monorepo": true, "serverless": true, "dataset": true, "datasets": true,
+	"refactoring": true, "refactored": true, "onboarding": true,
+	"observability": true, "containerized": true, "containerization": true,
+	"roadmap": true, "scalable": true, "uptime": true, "codebase": true,
+}
+
+var (
+	// technical matches the chunks that are not words: URLs, emails,
+	// technology names (Node.js, C#, CI/CD) and anything with a digit
+	technical = regexp.MustCompile(`://|@|^www\.|\pL[./#+_]|[./#+_]\pL|\d`)
+	elisions  = []string{"l'", "d'", "j'", "n'", "s'", "c'", "m'", "t'", "qu'"}
+)
+
+// words splits s in words to check, leaving out technical chunks,
+// acronyms (AWS) and words with inner capitals (GitHub)
+func words(s string) []word {
+	var result []word
+	previous := false // The previous chunk was a word ending without punctuation
+	for _, chunk := range strings.Fields(strings.ReplaceAll(s, "’", "'")) {
+		trimmed := strings.TrimFunc(chunk, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		if trimmed == "" || technical.MatchString(trimmed) {
+			previous = false
+			continue
+		}
+		result = append(result, word{text: trimmed, follow: previous && strings.HasPrefix(chunk, trimmed)})
+		previous = strings.HasSuffix(chunk, trimmed)
+	}
+	return result
+}
+
+// spellable reports whether a word should be looked up in the dictionaries
+func spellable(w string) bool {
+	runes := []rune(w)
+	if len(runes) < 2 || strings.ToUpper(w) == w {
+		return false
+	}
+	for _, r := range runes[1:] {
+		if unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// names returns the lower-case words of the names of cv (people,
+// companies, places, skills, projects), which are correct by definition
+func names(cv *models.CV) map[string]bool {
+	known := make(map[string]bool)
+	add := func(values ...string) {
+		for _, v := range values {
+			for _, w := range words(v) {
+				known[strings.ToLower(w.text)] = true
+			}
+		}
+	}
+
+	p := cv.Personal
+	add(p.FirstName, p.LastName, p.Location)
+	for _, exp := range cv.Experience {
+		add(exp.Company, exp.Location)
+	}
+	for _, edu := range cv.Education {
+		add(edu.Institution, edu.Location)
+	}
+	for _, category := range cv.Skills {
+		add(category.Items...)
+	}
+	for _, lang := range cv.Languages {
+		add(lang.Name)
+	}
+	for _, cert := range cv.Certifications {
+		add(cert.Name, cert.Issuer)
+	}
+	for _, project := range cv.Projects {
+		add(project.Name)
+		add(project.Technologies...)
+	}
+	return known
+}
+
+func checkSpelling(cv *models.CV, opts options) []Finding {
+	if opts.speller == nil {
+		return nil
+	}
+
+	known := names(cv)
+	correct := func(w string) bool {
+		if known[strings.ToLower(w)] || jargon[strings.ToLower(w)] || opts.speller.Check(w) {
+			return true
+		}
+		if _, ok := skills.Lookup(w); ok {
+			return true
+		}
+		// Elisions (l'équipe) and possessives (team's) the dictionary lacks
+		lower := strings.ToLower(w)
+		for _, elision := range elisions {
+			if strings.HasPrefix(lower, elision) && len(w) > len(elision) {
+				return opts.speller.Check(w[len(elision):])
+			}
+		}
+		if stem, ok := strings.CutSuffix(w, "'s"); ok {
+			return opts.speller.Check(stem)
+		}
+		return false
+	}
+
+	var findings []Finding
+	for _, t := range prose(cv) {
+		reported := make(map[string]bool)
+		for _, w := range words(t.value) {
+			if !spellable(w.text) || reported[w.text] || correct(w.text) {
+				continue
+			}
+			// Hyphenated words are correct when each part is
+			if parts := strings.Split(w.text, "-"); len(parts) > 1 {
+				ok := true
+				for _, part := range parts {
+					ok = ok && (part == "" || !spellable(part) || correct(part))
+				}
+				if ok {
+					continue
+				}
+			}
+
+			reported[w.text] = true
+			message := fmt.Sprintf("%q is not in the dictionary", w.text)
+			if suggestions := opts.speller.Suggest(w.text); len(suggestions) > 0 {
+				message += fmt.Sprintf(" (did you mean %s?)", quoteList(suggestions))
+			}
+			findings = append(findings, Finding{Path: t.path, Word: w.text, Message: message})
+		}
+	}
+	return findings
+}
+
+func checkRepeatedWords(cv *models.CV, opts options) []Finding {
+	if opts.speller == nil {
+		return nil
+	}
+
+	var findings []Finding
+	for _, t := range prose(cv) {
+		all := words(t.value)
+		for i := 1; i < len(all); i++ {
+			if all[i].follow && strings.EqualFold(all[i].text, all[i-1].text) {
+				findings = append(findings, Finding{
+					Path:    t.path,
+					Word:    all[i].text,
+					Message: fmt.Sprintf("%q is repeated", all[i-1].text+" "+all[i].text),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package match compares a job posting with a CV: it extracts the skill and
+// technology keywords of the posting and reports which ones the CV covers.
+package match
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"resumectl/internal/models"
+	"resumectl/internal/skills"
+)
+
+// CV sections searched for keywords
+const (
+	SourceSkills         = "skills"
+	SourceExperience     = "experience"
+	SourceProjects       = "projects"
+	SourceCertifications = "certifications"
+	SourceSummary        = "summary"
+)
+
+// maxOccurrences caps the weight of a keyword repeated in the posting
+const maxOccurrences = 3
+
+// Keyword is a skill or technology of the job posting
+type Keyword struct {
+	Name        string   // Canonical name
+	Occurrences int      // Mentions in the posting
+	Sources     []string // CV sections mentioning it
+}
+
+// Report is the result of a comparison
+type Report struct {
+	// Score is the share of keywords covered by the CV, from 0 to 100,
+	// keywords weighted by their mentions in the posting
+	Score       int
+	WellCovered []Keyword // Found in two sections or more of the CV
+	Covered     []Keyword // Found in one section
+	Missing     []Keyword // Not found in the CV
+}
+
+// Total returns the number of keywords of the posting
+func (r *Report) Total() int {
+	return len(r.WellCovered) + len(r.Covered) + len(r.Missing)
+}
+
+// Compare extracts the keywords of a job posting and looks for them in the
+// skills, experience (positions, descriptions and highlights), projects,
+// certifications and summary of cv. The skills and project technologies
+// of the CV count as keywords too, so that uncommon ones are recognized.
+func Compare(cv *models.CV, posting string) *Report {
+	vocab := newVocabulary(cv)
+
+	found := make(map[string][]string)
+	addSource := func(source, text string) {
+		for name := range vocab.scan(text) {
+			if len(found[name]) == 0 || found[name][len(found[name])-1] != source {
+				found[name] = append(found[name], source)
+			}
+		}
+	}
+	for _, category := range cv.Skills {
+		for _, item := range category.Items {
+			addSource(SourceSkills, item)
+		}
+	}
+	for _, exp := range cv.Experience {
+		addSource(SourceExperience, strings.Join(append([]string{exp.Position, exp.Description}, exp.Highlights...), "\n"))
+	}
+	for _, project := range cv.Projects {
+		addSource(SourceProjects, strings.Join(append([]string{project.Name, project.Description}, project.Technologies...), "\n"))
+	}
+	for _, cert := range cv.Certifications {
+		addSource(SourceCertifications, cert.Name)
+	}
+	addSource(SourceSummary, cv.Summary)
+
+	report := &Report{}
+	var covered, total float64
+	for name, occurrences := range vocab.scan(posting) {
+		keyword := Keyword{Name: name, Occurrences: occurrences, Sources: found[name]}
+		weight := float64(min(occurrences, maxOccurrences))
+		total += weight
+		switch len(keyword.Sources) {
+		case 0:
+			report.Missing = append(report.Missing, keyword)
+		case 1:
+			report.Covered = append(report.Covered, keyword)
+			covered += weight
+		default:
+			report.WellCovered = append(report.WellCovered, keyword)
+			covered += weight
+		}
+	}
+	if total > 0 {
+		report.Score = int(math.Round(100 * covered / total))
+	}
+
+	for _, list := range [][]Keyword{report.WellCovered, report.Covered, report.Missing} {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Occurrences != list[j].Occurrences {
+				return list[i].Occurrences > list[j].Occurrences
+			}
+			return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name)
+		})
+	}
+	return report
+}
+
+// maxWords is the length of the longest keyword, in words
+const maxWords = 3
+
+// vocabulary maps lowercase spellings to canonical keyword names
+type vocabulary map[string]string
+
+// newVocabulary returns the known technologies, plus the skills and
+// project technologies of cv
+func newVocabulary(cv *models.CV) vocabulary {
+	vocab := make(vocabulary, len(technologies))
+	for term, name := range technologies {
+		vocab[term] = name
+	}
+
+	add := func(term string) {
+		if _, known := vocab.lookup(strings.ToLower(strings.TrimSpace(term))); known {
+			return
+		}
+		// "JavaScript/TypeScript" lists two skills
+		for _, part := range strings.FieldsFunc(term, func(r rune) bool { return r == '/' || r == ',' }) {
+			key := strings.ToLower(strings.TrimSpace(part))
+			if len(strings.Fields(key)) == 0 || len(strings.Fields(key)) > maxWords {
+				continue
+			}
+			if _, known := vocab.lookup(key); !known {
+				vocab[key] = skills.Normalize(part)
+			}
+		}
+	}
+	for _, category := range cv.Skills {
+		for _, item := range category.Items {
+			add(item)
+		}
+	}
+	for _, project := range cv.Projects {
+		for _, tech := range project.Technologies {
+			add(tech)
+		}
+	}
+	return vocab
+}
+
+// lookup returns the canonical name of a lowercase term
+func (v vocabulary) lookup(term string) (string, bool) {
+	if name, ok := v[term]; ok {
+		return name, true
+	}
+	return skills.Lookup(term)
+}
+
+// token is a word of a text, as written and lowercased
+type token struct {
+	text, lower string
+}
+
+// scan returns the keywords of text with their number of occurrences.
+// The longest keyword wins ("Google Cloud Platform" over "Google Cloud"),
+// and words that are also common English words ("go", "rest") only count
+// when capitalized.
+func (v vocabulary) scan(text string) map[string]int {
+	tokens := tokenize(text)
+	result := make(map[string]int)
+
+	for i := 0; i < len(tokens); {
+		matched := 0
+		for n := min(maxWords, len(tokens)-i); n > 0; n-- {
+			words := make([]string, n)
+			for k := range words {
+				words[k] = tokens[i+k].lower
+			}
+			term := strings.Join(words, " ")
+			name, ok := v.lookup(term)
+			if !ok || n == 1 && ambiguous[term] && tokens[i].text == tokens[i].lower {
+				continue
+			}
+			result[name]++
+			matched = n
+			break
+		}
+
+		if matched == 0 && strings.Contains(tokens[i].lower, "/") {
+			// "Go/Python", unless known as a whole like "CI/CD"
+			for _, part := range strings.Split(tokens[i].text, "/") {
+				lower := strings.ToLower(part)
+				if name, ok := v.lookup(lower); ok && !(ambiguous[lower] && part == lower) {
+					result[name]++
+				}
+			}
+		}
+		i += max(matched, 1)
+	}
+	return result
+}
+
+// tokenize splits text in words, keeping the characters of technology
+// names (C++, C#, Node.js, .NET, CI/CD, scikit-learn) and dropping the
+// surrounding punctuation
+func tokenize(text string) []token {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("+#./-_", r)
+	})
+
+	var tokens []token
+	for _, field := range fields {
+		field = strings.TrimRight(field, ".-/")
+		field = strings.TrimLeft(field, "-/")
+		if field == "" {
+			continue
+		}
+		tokens = append(tokens, token{text: field, lower: strings.ToLower(field)})
+	}
+	return tokens
+}
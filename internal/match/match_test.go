@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package match
+
+import (
+	"reflect"
+	"testing"
+
+	"resumectl/internal/models"
+)
+
+func TestTokenize(t *testing.T) {
+	var got []string
+	for _, tok := range tokenize("(Node.js), -flag- CI/CD. C++/C#; .NET end.") {
+		got = append(got, tok.text)
+	}
+	want := []string{"Node.js", "flag", "CI/CD", "C++/C#", ".NET", "end"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tokenize = %q, want %q", got, want)
+	}
+}
+
+func TestScan(t *testing.T) {
+	vocab := newVocabulary(&models.CV{Skills: []models.SkillCategory{
+		{Items: []string{"Temporal", "gRPC/Protobuf"}},
+	}})
+
+	tests := []struct {
+		text string
+		want map[string]int
+	}{
+		{
+			text: "Experience with Go, Kubernetes and Google Cloud Platform.",
+			want: map[string]int{"Go": 1, "Kubernetes": 1, "GCP": 1},
+		},
+		{
+			// Ambiguous words only count when capitalized
+			text: "Let's go build a REST API, we rest on weekends",
+			want: map[string]int{"REST": 1},
+		},
+		{
+			text: "C++, C#, Node.js, .NET, CI/CD, scikit-learn",
+			want: map[string]int{"C++": 1, "C#": 1, "Node.js": 1, ".NET": 1, "CI/CD": 1, "scikit-learn": 1},
+		},
+		{
+			text: "Go/Python and Terraform/Ansible, k8s, Postgres and PostgreSQL",
+			want: map[string]int{"Go": 1, "Python": 1, "Terraform": 1, "Ansible": 1, "Kubernetes": 1, "PostgreSQL": 2},
+		},
+		{
+			// Skills of the CV are keywords too
+			text: "Temporal workflows with gRPC and Protobuf",
+			want: map[string]int{"Temporal": 1, "gRPC": 1, "Protobuf": 1},
+		},
+		{
+			text: "Nothing technical here.",
+			want: map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := vocab.scan(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("scan(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+// names returns the names of keywords, in order
+func names(keywords []Keyword) []string {
+	var result []string
+	for _, k := range keywords {
+		result = append(result, k.Name)
+	}
+	return result
+}
+
+func TestCompare(t *testing.T) {
+	cv := &models.CV{
+		Summary: "Backend engineer working with Go and PostgreSQL.",
+		Skills: []models.SkillCategory{
+			{Category: "Languages", Items: []string{"Go", "Python"}},
+			{Category: "Infrastructure", Items: []string{"Docker"}},
+		},
+		Experience: []models.Experience{{
+			Position:   "Backend Engineer",
+			Highlights: []string{"Moved 40 services to Kubernetes", "Wrote the Go SDK"},
+		}},
+	}
+	posting := `We are looking for a Go engineer. You know Go, Kubernetes and PostgreSQL.
+Go, Go again. Kafka is a plus, and so is Terraform.`
+
+	report := Compare(cv, posting)
+
+	// Go appears 4 times but weighs maxOccurrences
+	if want := []string{"Go", "Kubernetes", "PostgreSQL"}; !reflect.DeepEqual(names(report.WellCovered), []string{"Go"}) ||
+		!reflect.DeepEqual(append(names(report.WellCovered), names(report.Covered)...), want) {
+		t.Errorf("covered = %q and %q, want %q", names(report.WellCovered), names(report.Covered), want)
+	}
+	if want := []string{"Kafka", "Terraform"}; !reflect.DeepEqual(names(report.Missing), want) {
+		t.Errorf("missing = %q, want %q", names(report.Missing), want)
+	}
+	if got, want := report.WellCovered[0].Sources, []string{SourceSkills, SourceExperience, SourceSummary}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Go sources = %q, want %q", got, want)
+	}
+	if report.WellCovered[0].Occurrences != 4 {
+		t.Errorf("Go occurrences = %d, want 4", report.WellCovered[0].Occurrences)
+	}
+	// Covered: Go 3 + Kubernetes 1 + PostgreSQL 1, out of 7
+	if report.Score != 71 {
+		t.Errorf("Score = %d, want 71", report.Score)
+	}
+	if report.Total() != 5 {
+		t.Errorf("Total = %d, want 5", report.Total())
+	}
+}
+
+func TestCompareEmptyPosting(t *testing.T) {
+	report := Compare(&models.CV{}, "We value kindness.")
+	if report.Score != 0 || report.Total() != 0 {
+		t.Errorf("report = %+v, want no keywords", report)
+	}
+}
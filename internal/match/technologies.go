@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package match
+
+// technologies are the keywords recognized in job postings besides the
+// skill aliases of the skills package, by lowercase spelling
+var technologies = map[string]string{
+	// Languages
+	"scala":       "Scala",
+	"swift":       "Swift",
+	"objective-c": "Objective-C",
+	"elixir":      "Elixir",
+	"erlang":      "Erlang",
+	"haskell":     "Haskell",
+	"clojure":     "Clojure",
+	"ocaml":       "OCaml",
+	"matlab":      "MATLAB",
+	"perl":        "Perl",
+	"lua":         "Lua",
+	"dart":        "Dart",
+	"zig":         "Zig",
+	"solidity":    "Solidity",
+	"sql":         "SQL",
+	"html":        "HTML",
+	"html5":       "HTML",
+	"css":         "CSS",
+	"css3":        "CSS",
+	"sass":        "Sass",
+	"scss":        "Sass",
+	"powershell":  "PowerShell",
+
+	// Data stores and messaging
+	"postgresql":    "PostgreSQL",
+	"postgres":      "PostgreSQL",
+	"mysql":         "MySQL",
+	"mariadb":       "MariaDB",
+	"sqlite":        "SQLite",
+	"oracle":        "Oracle",
+	"sql server":    "SQL Server",
+	"mongodb":       "MongoDB",
+	"redis":         "Redis",
+	"cassandra":     "Cassandra",
+	"dynamodb":      "DynamoDB",
+	"elasticsearch": "Elasticsearch",
+	"opensearch":    "OpenSearch",
+	"clickhouse":    "ClickHouse",
+	"snowflake":     "Snowflake",
+	"bigquery":      "BigQuery",
+	"kafka":         "Kafka",
+	"rabbitmq":      "RabbitMQ",
+	"nats":          "NATS",
+	"nosql":         "NoSQL",
+
+	// Data and machine learning
+	"spark":            "Spark",
+	"hadoop":           "Hadoop",
+	"airflow":          "Airflow",
+	"dbt":              "dbt",
+	"pandas":           "pandas",
+	"numpy":            "NumPy",
+	"scikit-learn":     "scikit-learn",
+	"keras":            "Keras",
+	"machine learning": "Machine Learning",
+	"deep learning":    "Deep Learning",
+	"nlp":              "NLP",
+	"llm":              "LLM",
+	"llms":             "LLM",
+	"mlops":            "MLOps",
+	"computer vision":  "Computer Vision",
+
+	// Infrastructure and operations
+	"google cloud":          "GCP",
+	"google cloud platform": "GCP",
+	"openshift":             "OpenShift",
+	"istio":                 "Istio",
+	"vault":                 "Vault",
+	"consul":                "Consul",
+	"nomad":                 "Nomad",
+	"packer":                "Packer",
+	"puppet":                "Puppet",
+	"chef":                  "Chef",
+	"saltstack":             "SaltStack",
+	"nginx":                 "NGINX",
+	"haproxy":               "HAProxy",
+	"cloudformation":        "CloudFormation",
+	"lambda":                "AWS Lambda",
+	"serverless":            "Serverless",
+	"openstack":             "OpenStack",
+	"vmware":                "VMware",
+	"jenkins":               "Jenkins",
+	"circleci":              "CircleCI",
+	"ci/cd":                 "CI/CD",
+	"cicd":                  "CI/CD",
+	"gitops":                "GitOps",
+	"argo cd":               "Argo CD",
+	"flux":                  "Flux",
+	"git":                   "Git",
+	"datadog":               "Datadog",
+	"splunk":                "Splunk",
+	"elk":                   "ELK",
+	"opentelemetry":         "OpenTelemetry",
+	"loki":                  "Loki",
+	"sre":                   "SRE",
+	"devops":                "DevOps",
+	"microservices":         "Microservices",
+	"unix":                  "Unix",
+	"windows server":        "Windows Server",
+	"bash scripting":        "Shell",
+	"networking":            "Networking",
+	"tcp/ip":                "TCP/IP",
+	"dns":                   "DNS",
+	"bgp":                   "BGP",
+
+	// Web, mobile and APIs
+	"node.js":      "Node.js",
+	".net":         ".NET",
+	"rest":         "REST",
+	"restful":      "REST",
+	"grpc":         "gRPC",
+	"websocket":    "WebSocket",
+	"websockets":   "WebSocket",
+	"oauth":        "OAuth",
+	"oauth2":       "OAuth",
+	"openapi":      "OpenAPI",
+	"redux":        "Redux",
+	"jquery":       "jQuery",
+	"nuxt":         "Nuxt",
+	"nestjs":       "NestJS",
+	"webpack":      "webpack",
+	"vite":         "Vite",
+	"jest":         "Jest",
+	"cypress":      "Cypress",
+	"playwright":   "Playwright",
+	"selenium":     "Selenium",
+	"ios":          "iOS",
+	"android":      "Android",
+	"react native": "React Native",
+	"swiftui":      "SwiftUI",
+	"unity":        "Unity",
+	"wordpress":    "WordPress",
+
+	// Practices
+	"tdd":    "TDD",
+	"bdd":    "BDD",
+	"agile":  "Agile",
+	"scrum":  "Scrum",
+	"kanban": "Kanban",
+}
+
+// ambiguous are the keywords that are also common English words: they only
+// count when capitalized in the text ("Go", not "go live")
+var ambiguous = map[string]bool{
+	"go":      true,
+	"c":       true,
+	"r":       true,
+	"rest":    true,
+	"spring":  true,
+	"express": true,
+	"flux":    true,
+	"swift":   true,
+	"node":    true,
+	"dart":    true,
+	"react":   true,
+	"helm":    true,
+	"rust":    true,
+	"shell":   true,
+	"vault":   true,
+	"consul":  true,
+	"nomad":   true,
+	"packer":  true,
+	"puppet":  true,
+	"chef":    true,
+	"oracle":  true,
+	"spark":   true,
+	"lambda":  true,
+	"jest":    true,
+	"unity":   true,
+	"ts":      true,
+	"py":      true,
+	"nix":     true,
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package merge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 2
+
+// diffLine is one line of an alignment between two texts
+type diffLine struct {
+	op   byte // ' ' (common), '-' (removed) or '+' (added)
+	text string
+	num  int // line number in the old text, or in the new one for additions
+}
+
+// align computes a minimal line alignment between a and b using the
+// longest common subsequence
+func align(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i], i + 1})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			// Removed lines come before the lines replacing them
+			lines = append(lines, diffLine{'-', a[i], i + 1})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j], j + 1})
+			j++
+		}
+	}
+	return lines
+}
+
+// Diff returns a line-based unified diff between two texts, or "" when equal
+func Diff(before, after string) string {
+	lines := align(splitLines(before), splitLines(after))
+
+	// Keep only changed lines and their context
+	show := make([]bool, len(lines))
+	changed := false
+	for k, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		changed = true
+		for c := max(0, k-diffContext); c <= min(len(lines)-1, k+diffContext); c++ {
+			show[c] = true
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var sb strings.Builder
+	for k, l := range lines {
+		if !show[k] {
+			continue
+		}
+		if k == 0 || !show[k-1] {
+			sb.WriteString(fmt.Sprintf("@@ line %d @@\n", l.num))
+		}
+		sb.WriteString(fmt.Sprintf("%c %s\n", l.op, l.text))
+	}
+	return sb.String()
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,531 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package merge
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"resumectl/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Result describes the changes made by a merge
+type Result struct {
+	Updated   []string // Fields whose value was replaced by imported data
+	Added     []string // Fields and list entries that were added
+	Conflicts []string // Fields edited both locally and upstream (local value kept)
+}
+
+// HasChanges reports whether the merge modified the document
+func (r *Result) HasChanges() bool {
+	return len(r.Updated) > 0 || len(r.Added) > 0
+}
+
+// identityKeys tells how entries of a top-level list are matched between
+// the local file and imported data. Start dates are compared normalized, so
+// "9/2020", "09/2020" and "2020-09" identify the same entry.
+var identityKeys = map[string]func(item *yaml.Node) string{
+	"experience": func(item *yaml.Node) string {
+		return joinKey(field(item, "company"), models.NormalizeDate(field(item, "startDate")))
+	},
+	"education": func(item *yaml.Node) string {
+		return joinKey(field(item, "institution"), models.NormalizeDate(field(item, "startDate")))
+	},
+	"projects": func(item *yaml.Node) string {
+		return normalizeURL(field(item, "url"))
+	},
+	"skills": func(item *yaml.Node) string {
+		return normalizeText(field(item, "category"))
+	},
+	"languages": func(item *yaml.Node) string {
+		return normalizeText(field(item, "name"))
+	},
+	"certifications": func(item *yaml.Node) string {
+		return normalizeText(field(item, "name"))
+	},
+}
+
+// CV merges freshly imported data (theirs) into a parsed cv.yaml document.
+// base is the data produced by the previous import, if any: it is used to
+// tell local hand edits (always kept) from upstream changes (applied).
+// Comments and key order of doc are preserved.
+func CV(doc *yaml.Node, base, theirs *models.CV) (*Result, error) {
+	root := doc
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil, fmt.Errorf("empty YAML document")
+		}
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("CV file must contain a YAML mapping at the top level")
+	}
+
+	theirsNode, err := toNode(theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseNode *yaml.Node
+	if base != nil {
+		if baseNode, err = toNode(base); err != nil {
+			return nil, err
+		}
+	}
+
+	m := &merger{result: &Result{}}
+	m.mapping(root, baseNode, theirsNode, "")
+	return m.result, nil
+}
+
+// toNode encodes a CV as a YAML node tree
+func toNode(cv *models.CV) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(cv); err != nil {
+		return nil, fmt.Errorf("failed to encode CV: %w", err)
+	}
+	return &node, nil
+}
+
+type merger struct {
+	result *Result
+}
+
+// value merges theirs into ours, dispatching on the node kind
+func (m *merger) value(ours, base, theirs *yaml.Node, path string) {
+	if isEmpty(theirs) {
+		return
+	}
+
+	// An empty local value (e.g. "projects:" or "projects: []") is simply replaced
+	if isEmpty(ours) && (base == nil || isEmpty(base)) {
+		replaceNode(ours, theirs)
+		m.result.Added = append(m.result.Added, path)
+		return
+	}
+
+	if ours.Kind != theirs.Kind {
+		m.result.Conflicts = append(m.result.Conflicts, path)
+		return
+	}
+	if base != nil && base.Kind != theirs.Kind {
+		base = nil
+	}
+
+	switch theirs.Kind {
+	case yaml.ScalarNode:
+		m.scalar(ours, base, theirs, path)
+	case yaml.MappingNode:
+		m.mapping(ours, base, theirs, path)
+	case yaml.SequenceNode:
+		m.sequence(ours, base, theirs, path)
+	}
+}
+
+// scalar performs a three-way merge of a single value
+func (m *merger) scalar(ours, base, theirs *yaml.Node, path string) {
+	if ours.Value == theirs.Value {
+		return
+	}
+
+	switch {
+	case base == nil:
+		// Never imported before: only fill in blanks
+		if isEmpty(ours) {
+			setScalar(ours, theirs)
+			m.result.Updated = append(m.result.Updated, path)
+		}
+	case ours.Value == base.Value:
+		// Unchanged locally, changed upstream
+		setScalar(ours, theirs)
+		m.result.Updated = append(m.result.Updated, path)
+	case theirs.Value == base.Value:
+		// Changed locally only: keep the hand edit
+	default:
+		m.result.Conflicts = append(m.result.Conflicts, path)
+	}
+}
+
+// mapping merges each key of theirs into ours, appending new keys at the end
+func (m *merger) mapping(ours, base, theirs *yaml.Node, path string) {
+	for i := 0; i+1 < len(theirs.Content); i += 2 {
+		key := theirs.Content[i].Value
+		tv := theirs.Content[i+1]
+		ov := lookup(ours, key)
+		bv := lookup(base, key)
+		childPath := joinPath(path, key)
+
+		if ov == nil {
+			// Deleted by hand since the last import: respect it
+			if bv != nil {
+				continue
+			}
+			// Mappings of empty fields (e.g. an unset personal section) are not added
+			added := pruneEmpty(copyNode(tv))
+			if isEmpty(added) {
+				continue
+			}
+			ours.Content = append(ours.Content, copyNode(theirs.Content[i]), added)
+			m.result.Added = append(m.result.Added, childPath)
+			continue
+		}
+
+		m.value(ov, bv, tv, childPath)
+	}
+}
+
+// sequence merges lists, matching entries by identity when possible
+func (m *merger) sequence(ours, base, theirs *yaml.Node, path string) {
+	if allScalars(theirs) {
+		m.scalarSequence(ours, base, theirs, path)
+		return
+	}
+
+	keyFn, ok := identityKeys[path]
+	if !ok {
+		// No way to match entries reliably: leave the local list alone
+		return
+	}
+
+	insertAt := 0
+	for _, item := range theirs.Content {
+		key := keyFn(item)
+		if key == "" {
+			continue
+		}
+		label := fmt.Sprintf("%s[%s]", path, key)
+
+		if idx := indexByKey(ours, keyFn, key); idx >= 0 {
+			m.value(ours.Content[idx], findByKey(base, keyFn, key), item, label)
+			insertAt = idx + 1
+			continue
+		}
+		if findByKey(base, keyFn, key) != nil {
+			// Removed by hand since the last import
+			continue
+		}
+
+		ours.Content = insertNode(ours.Content, insertAt, pruneEmpty(copyNode(item)))
+		insertAt++
+		m.result.Added = append(m.result.Added, label)
+	}
+}
+
+// scalarSequence merges lists of plain values (highlights, technologies...)
+// as a set union, skipping values removed by hand since the last import
+func (m *merger) scalarSequence(ours, base, theirs *yaml.Node, path string) {
+	for _, item := range theirs.Content {
+		if containsValue(ours, item.Value) || containsValue(base, item.Value) {
+			continue
+		}
+		ours.Content = append(ours.Content, copyNode(item))
+		m.result.Added = append(m.result.Added, fmt.Sprintf("%s[%s]", path, item.Value))
+	}
+}
+
+// Encode serializes a document with the given indentation
+func Encode(doc *yaml.Node, indent int) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DetectIndent returns the indentation width used by a YAML file (default 4,
+// as written by yaml.Marshal)
+func DetectIndent(data []byte) int {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+		if indent > 0 && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return indent
+		}
+	}
+	return 4
+}
+
+// SplitPreamble separates the leading comments, blank lines and "---"
+// marker of a YAML file from its body. yaml.v3 does not round-trip them
+// reliably, so callers parse the body only and put the preamble back verbatim.
+func SplitPreamble(data []byte) (preamble, body []byte) {
+	lines := strings.SplitAfter(string(data), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "---" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		return []byte(strings.Join(lines[:i], "")), []byte(strings.Join(lines[i:], ""))
+	}
+	return data, nil
+}
+
+// RestoreBlankLines re-inserts the blank lines of the original file, which
+// yaml.v3 drops when re-encoding, before lines that survived unchanged
+func RestoreBlankLines(original, encoded []byte) []byte {
+	origLines := splitLines(string(original))
+	blankBefore := make(map[int]bool)
+	sectionBreaks := make(map[string]bool) // top-level keys preceded by a blank line
+	for i := 1; i < len(origLines); i++ {
+		if strings.TrimSpace(origLines[i-1]) == "" {
+			blankBefore[i+1] = true
+			if key := topLevelKey(origLines[i]); key != "" {
+				sectionBreaks[key] = true
+			}
+		}
+	}
+
+	// Align non-blank lines only, remembering their original line numbers
+	var nonBlank []string
+	var origNum []int
+	for i, line := range origLines {
+		if strings.TrimSpace(line) != "" {
+			nonBlank = append(nonBlank, line)
+			origNum = append(origNum, i+1)
+		}
+	}
+
+	// Lines are compared without the padding before line comments, which
+	// the encoder does not keep, and unchanged lines are taken verbatim
+	encodedLines := splitLines(string(encoded))
+	var out []string
+	k, j := 0, 0 // indexes into nonBlank and encodedLines
+	for _, l := range align(commentPadding(nonBlank), commentPadding(encodedLines)) {
+		switch l.op {
+		case ' ':
+			if blankBefore[origNum[k]] && len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+			out = append(out, nonBlank[k])
+			k++
+			j++
+		case '-':
+			k++
+		case '+':
+			if sectionBreaks[topLevelKey(l.text)] && len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+			out = append(out, encodedLines[j])
+			j++
+		}
+	}
+	return []byte(strings.Join(out, "\n") + "\n")
+}
+
+// commentPadding returns lines with the spaces before a line comment
+// reduced to one
+func commentPadding(lines []string) []string {
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		if idx := strings.Index(line, " #"); idx > 0 && strings.TrimSpace(line[:idx]) != "" {
+			line = strings.TrimRight(line[:idx], " ") + line[idx:]
+		}
+		result[i] = line
+	}
+	return result
+}
+
+// topLevelKey returns the key of an unindented "key: value" line
+func topLevelKey(line string) string {
+	if line == "" || line[0] == ' ' || line[0] == '#' || line[0] == '-' {
+		return ""
+	}
+	if idx := strings.Index(line, ":"); idx > 0 {
+		return line[:idx]
+	}
+	return ""
+}
+
+// lookup returns the value of key in a mapping node, or nil
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// field returns the scalar value of key in a mapping node
+func field(node *yaml.Node, key string) string {
+	if v := lookup(node, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
+
+func indexByKey(seq *yaml.Node, keyFn func(*yaml.Node) string, key string) int {
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		return -1
+	}
+	for i, item := range seq.Content {
+		if keyFn(item) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func findByKey(seq *yaml.Node, keyFn func(*yaml.Node) string, key string) *yaml.Node {
+	if idx := indexByKey(seq, keyFn, key); idx >= 0 {
+		return seq.Content[idx]
+	}
+	return nil
+}
+
+func containsValue(seq *yaml.Node, value string) bool {
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		return false
+	}
+	for _, item := range seq.Content {
+		if strings.EqualFold(strings.TrimSpace(item.Value), strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+func allScalars(seq *yaml.Node) bool {
+	for _, item := range seq.Content {
+		if item.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmpty reports whether a node holds a zero value
+func isEmpty(node *yaml.Node) bool {
+	if node == nil {
+		return true
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!null":
+			return true
+		case "!!bool":
+			return node.Value == "false"
+		case "!!int", "!!float":
+			return node.Value == "0"
+		}
+		return node.Value == ""
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(node.Content) == 0
+	}
+	return false
+}
+
+// setScalar replaces the value of ours, keeping its comments
+func setScalar(ours, theirs *yaml.Node) {
+	ours.Value = theirs.Value
+	ours.Tag = theirs.Tag
+	if strings.Contains(theirs.Value, "\n") {
+		ours.Style = yaml.LiteralStyle
+	} else if ours.Style == yaml.LiteralStyle || ours.Style == yaml.FoldedStyle {
+		ours.Style = 0
+	}
+}
+
+// replaceNode replaces the content of ours with a copy of theirs, keeping its comments
+func replaceNode(ours, theirs *yaml.Node) {
+	cp := copyNode(theirs)
+	cp.HeadComment = ours.HeadComment
+	cp.LineComment = ours.LineComment
+	cp.FootComment = ours.FootComment
+	*ours = *cp
+}
+
+func copyNode(node *yaml.Node) *yaml.Node {
+	cp := *node
+	cp.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		cp.Content[i] = copyNode(child)
+	}
+	return &cp
+}
+
+// pruneEmpty removes zero-valued keys from mappings so that added entries
+// don't carry a list of blank fields
+func pruneEmpty(node *yaml.Node) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		var content []*yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if v := pruneEmpty(node.Content[i+1]); !isEmpty(v) {
+				content = append(content, node.Content[i], v)
+			}
+		}
+		node.Content = content
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			node.Content[i] = pruneEmpty(child)
+		}
+	}
+	return node
+}
+
+func insertNode(nodes []*yaml.Node, idx int, node *yaml.Node) []*yaml.Node {
+	nodes = append(nodes, nil)
+	copy(nodes[idx+1:], nodes[idx:])
+	nodes[idx] = node
+	return nodes
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// joinKey builds a composite identity; entries without a name have none
+func joinKey(name string, qualifiers ...string) string {
+	key := normalizeText(name)
+	if key == "" {
+		return ""
+	}
+	for _, q := range qualifiers {
+		if q = normalizeText(q); q != "" {
+			key += " @ " + q
+		}
+	}
+	return key
+}
+
+func normalizeText(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// normalizeURL makes "https://www.github.com/a/b/" and "github.com/a/b" equal
+func normalizeURL(u string) string {
+	u = normalizeText(u)
+	u = strings.TrimPrefix(u, "https://")
+	u = strings.TrimPrefix(u, "http://")
+	u = strings.TrimPrefix(u, "www.")
+	return strings.TrimSuffix(u, "/")
+}
@@ -0,0 +1,460 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package merge
+
+import (
+	"reflect"
+	"testing"
+
+	"resumectl/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseCV decodes an imported CV written as YAML, or returns nil for ""
+func parseCV(t *testing.T, data string) *models.CV {
+	t.Helper()
+	if data == "" {
+		return nil
+	}
+	var cv models.CV
+	if err := yaml.Unmarshal([]byte(data), &cv); err != nil {
+		t.Fatalf("parsing CV: %v", err)
+	}
+	return &cv
+}
+
+// mergeFile merges imported data into a cv.yaml file the way "resumectl
+// sync" does and returns the new file
+func mergeFile(t *testing.T, local string, base, theirs *models.CV) (string, *Result) {
+	t.Helper()
+	preamble, body := SplitPreamble([]byte(local))
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("parsing local file: %v", err)
+	}
+	result, err := CV(&doc, base, theirs)
+	if err != nil {
+		t.Fatalf("CV: %v", err)
+	}
+
+	encoded, err := Encode(&doc, DetectIndent(body))
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	return string(append(preamble, RestoreBlankLines(body, encoded)...)), result
+}
+
+func TestCV(t *testing.T) {
+	tests := []struct {
+		name   string
+		local  string
+		base   string
+		theirs string
+		want   string // Expected file, the local one when empty
+		result Result
+	}{
+		{
+			name: "local edit kept",
+			local: `personal:
+  firstName: Jane
+  title: Staff Engineer # promoted
+`,
+			base: `personal:
+  firstName: Jane
+  title: Engineer
+`,
+			theirs: `personal:
+  firstName: Jane
+  title: Engineer
+`,
+		},
+		{
+			name: "upstream change applied",
+			local: `personal:
+  firstName: Jane
+  location: Paris
+`,
+			base: `personal:
+  firstName: Jane
+  location: Paris
+`,
+			theirs: `personal:
+  firstName: Jane
+  location: Lyon
+`,
+			want: `personal:
+  firstName: Jane
+  location: Lyon
+`,
+			result: Result{Updated: []string{"personal.location"}},
+		},
+		{
+			name: "conflict reported",
+			local: `personal:
+  firstName: Jane
+  location: Nantes
+`,
+			base: `personal:
+  firstName: Jane
+  location: Paris
+`,
+			theirs: `personal:
+  firstName: Jane
+  location: Lyon
+`,
+			result: Result{Conflicts: []string{"personal.location"}},
+		},
+		{
+			name: "first import fills blanks only",
+			local: `personal:
+  firstName: Jane
+  title: Engineer
+  email: ""
+`,
+			theirs: `personal:
+  firstName: Janet
+  title: Developer
+  email: jane@example.com
+`,
+			want: `personal:
+  firstName: Jane
+  title: Engineer
+  email: jane@example.com
+`,
+			result: Result{Added: []string{"personal.email"}},
+		},
+		{
+			name: "deleted entry not re-added",
+			local: `experience:
+  - company: Globex
+    startDate: "2022-01"
+`,
+			base: `experience:
+  - company: Globex
+    startDate: "2022-01"
+  - company: Acme
+    startDate: "2018-03"
+`,
+			theirs: `experience:
+  - company: Globex
+    startDate: "2022-01"
+  - company: Acme
+    startDate: "2018-03"
+`,
+		},
+		{
+			name: "deleted highlight not re-added",
+			local: `experience:
+  - company: Acme
+    startDate: "2018-03"
+    highlights:
+      - Shipped the API
+`,
+			base: `experience:
+  - company: Acme
+    startDate: "2018-03"
+    highlights: [Shipped the API, Fixed bugs]
+`,
+			theirs: `experience:
+  - company: Acme
+    startDate: "2018-03"
+    highlights: [Shipped the API, Fixed bugs]
+`,
+		},
+		{
+			name: "new entry added after its predecessor",
+			local: `experience:
+  - company: Globex
+    startDate: "2022-01"
+`,
+			base: `experience:
+  - company: Globex
+    startDate: "2022-01"
+`,
+			theirs: `experience:
+  - company: Globex
+    startDate: "2022-01"
+  - company: Acme
+    position: Developer
+    startDate: "2018-03"
+`,
+			want: `experience:
+  - company: Globex
+    startDate: "2022-01"
+  - company: Acme
+    position: Developer
+    startDate: 2018-03
+`,
+			result: Result{Added: []string{"experience[acme @ 2018-03]"}},
+		},
+		{
+			name: "experience identity survives a date format change",
+			local: `experience:
+  - company: Acme
+    startDate: "2020-09"
+    highlights:
+      - Shipped the API
+`,
+			theirs: `experience:
+  - company: ACME
+    startDate: 09/2020
+    highlights: [Shipped the API, Led the migration]
+`,
+			want: `experience:
+  - company: Acme
+    startDate: "2020-09"
+    highlights:
+      - Shipped the API
+      - Led the migration
+`,
+			result: Result{Added: []string{"experience[acme @ 2020-09].highlights[Led the migration]"}},
+		},
+		{
+			name: "education identity survives an unpadded month",
+			local: `education:
+  - institution: MIT
+    startDate: 9/2014
+    degree: MSc
+`,
+			theirs: `education:
+  - institution: MIT
+    startDate: 09/2014
+    degree: MSc
+    field: Computer Science
+`,
+			want: `education:
+  - institution: MIT
+    startDate: 9/2014
+    degree: MSc
+    field: Computer Science
+`,
+			result: Result{Added: []string{"education[mit @ 2014-09].field"}},
+		},
+		{
+			name: "comments and blank lines preserved",
+			local: `# My CV
+# Edit by hand
+
+personal:
+  firstName: Jane # legal name
+  location: Paris
+
+# Jobs, most recent first
+experience:
+  - company: Globex
+    startDate: "2022-01"
+`,
+			base: `personal:
+  firstName: Jane
+  location: Paris
+experience:
+  - company: Globex
+    startDate: "2022-01"
+`,
+			theirs: `personal:
+  firstName: Jane
+  location: Lyon
+experience:
+  - company: Globex
+    startDate: "2022-01"
+`,
+			want: `# My CV
+# Edit by hand
+
+personal:
+  firstName: Jane # legal name
+  location: Lyon
+
+# Jobs, most recent first
+experience:
+  - company: Globex
+    startDate: "2022-01"
+`,
+			result: Result{Updated: []string{"personal.location"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, result := mergeFile(t, tt.local, parseCV(t, tt.base), parseCV(t, tt.theirs))
+
+			want := tt.want
+			if want == "" {
+				want = tt.local
+			}
+			if got != want {
+				t.Errorf("merged file:\n%s\nwant:\n%s\ndiff:\n%s", got, want, Diff(want, got))
+			}
+			if !reflect.DeepEqual(*result, tt.result) {
+				t.Errorf("result = %+v, want %+v", *result, tt.result)
+			}
+		})
+	}
+}
+
+// TestFoldAlternatingSyncs runs a GitHub sync then a LinkedIn sync the way
+// "resumectl sync" does, storing the folded snapshot after each run
+func TestFoldAlternatingSyncs(t *testing.T) {
+	// Globex and the "old" project were deleted by hand after the first import
+	local := `personal:
+  firstName: Jane
+  github: github.com/jane
+experience:
+  - company: Acme
+    position: Engineer
+    startDate: "2020-01"
+projects:
+  - name: tool
+    url: https://github.com/jane/tool
+`
+	snapshot := parseCV(t, `personal:
+  firstName: Jane
+  github: github.com/jane
+experience:
+  - company: Acme
+    position: Engineer
+    startDate: "2020-01"
+  - company: Globex
+    position: Intern
+    startDate: "2018-06"
+projects:
+  - name: tool
+    url: https://github.com/jane/tool
+  - name: old
+    url: https://github.com/jane/old
+`)
+
+	github := parseCV(t, `personal:
+  github: github.com/jane
+projects:
+  - name: tool
+    url: https://github.com/jane/tool
+  - name: old
+    url: https://github.com/jane/old
+  - name: new
+    url: https://github.com/jane/new
+`)
+	local, result := mergeFile(t, local, snapshot, github)
+	if want := (Result{Added: []string{"projects[github.com/jane/new]"}}); !reflect.DeepEqual(*result, want) {
+		t.Errorf("GitHub sync result = %+v, want %+v", *result, want)
+	}
+	snapshot = Fold(snapshot, github, Sources{Projects: true})
+
+	if len(snapshot.Experience) != 2 {
+		t.Fatalf("GitHub sync dropped the LinkedIn data from the snapshot: %+v", snapshot.Experience)
+	}
+
+	linkedin := parseCV(t, `personal:
+  firstName: Jane
+experience:
+  - company: Acme
+    position: Senior Engineer
+    startDate: "2020-01"
+  - company: Globex
+    position: Intern
+    startDate: "2018-06"
+`)
+	got, result := mergeFile(t, local, snapshot, linkedin)
+	if want := (Result{Updated: []string{"experience[acme @ 2020-01].position"}}); !reflect.DeepEqual(*result, want) {
+		t.Errorf("LinkedIn sync result = %+v, want %+v", *result, want)
+	}
+	want := `personal:
+  firstName: Jane
+  github: github.com/jane
+experience:
+  - company: Acme
+    position: Senior Engineer
+    startDate: "2020-01"
+projects:
+  - name: tool
+    url: https://github.com/jane/tool
+  - name: new
+    url: https://github.com/jane/new
+`
+	if got != want {
+		t.Errorf("merged file:\n%s\nwant:\n%s\ndiff:\n%s", got, want, Diff(want, got))
+	}
+
+	snapshot = Fold(snapshot, linkedin, Sources{LinkedIn: true})
+	if snapshot.Personal.GitHub != "github.com/jane" || len(snapshot.Projects) != 3 {
+		t.Errorf("LinkedIn sync dropped the project data from the snapshot: %+v", snapshot)
+	}
+	if snapshot.Experience[0].Position != "Senior Engineer" {
+		t.Errorf("snapshot experience = %+v, want the LinkedIn update", snapshot.Experience)
+	}
+}
+
+func TestRestoreBlankLines(t *testing.T) {
+	original := `personal:
+  firstName: Jane
+
+
+skills:
+  - category: Languages
+    items: [Go]
+
+projects: []
+`
+	// As re-encoded by yaml.v3 after a project was added
+	encoded := `personal:
+  firstName: Jane
+skills:
+  - category: Languages
+    items: [Go]
+projects:
+  - name: resumectl
+`
+	want := `personal:
+  firstName: Jane
+
+skills:
+  - category: Languages
+    items: [Go]
+
+projects:
+  - name: resumectl
+`
+	if got := string(RestoreBlankLines([]byte(original), []byte(encoded))); got != want {
+		t.Errorf("RestoreBlankLines:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	after := "1\ntwo\n3\n4\n5\n6\n7\n8\n9\n10\n11\nx\n12\n"
+
+	// Changes are shown with two lines of context, replaced lines first
+	want := `@@ line 1 @@
+  1
+- 2
++ two
+  3
+  4
+@@ line 10 @@
+  10
+  11
++ x
+  12
+`
+	if got := Diff(before, after); got != want {
+		t.Errorf("Diff:\n%s\nwant:\n%s", got, want)
+	}
+	if got := Diff(before, before); got != "" {
+		t.Errorf("Diff of equal texts = %q, want \"\"", got)
+	}
+}
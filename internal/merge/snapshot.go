@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package merge
+
+import (
+	"strings"
+
+	"resumectl/internal/models"
+)
+
+// Sources tells which imports produced a CV
+type Sources struct {
+	LinkedIn bool // Profile, summary, experience, education, languages, certifications
+	Projects bool // GitHub, GitLab and --forge projects, and the skills derived from them
+}
+
+// Fold returns the import snapshot to store after a sync: previous (the
+// last snapshot, may be nil) where only the sections owned by the sources
+// fetched this run are replaced by imported. Without it, a GitHub-only sync
+// would drop the LinkedIn data from the snapshot and the next LinkedIn sync
+// would take every hand deletion for a new entry.
+func Fold(previous, imported *models.CV, from Sources) *models.CV {
+	if previous == nil {
+		return imported
+	}
+
+	folded := *previous
+	if from.LinkedIn {
+		github := folded.Personal.GitHub
+		folded.Personal = imported.Personal
+		if folded.Personal.GitHub == "" {
+			folded.Personal.GitHub = github
+		}
+		folded.Summary = imported.Summary
+		folded.Experience = imported.Experience
+		folded.Education = imported.Education
+		folded.Languages = imported.Languages
+		folded.Certifications = imported.Certifications
+	}
+	if from.Projects {
+		if imported.Personal.GitHub != "" {
+			folded.Personal.GitHub = imported.Personal.GitHub
+		}
+		folded.Projects = foldProjects(previous.Projects, imported.Projects)
+	}
+	folded.Skills = foldSkills(previous.Skills, imported.Skills)
+
+	return &folded
+}
+
+// foldProjects keeps the previous projects, replacing the ones imported
+// again (matched by URL) and appending the new ones. Projects of a forge
+// not fetched this run stay in the snapshot.
+func foldProjects(previous, imported []models.Project) []models.Project {
+	folded := append([]models.Project(nil), previous...)
+	for _, proj := range imported {
+		found := false
+		for i := range folded {
+			if normalizeURL(folded[i].URL) == normalizeURL(proj.URL) {
+				folded[i] = proj
+				found = true
+				break
+			}
+		}
+		if !found {
+			folded = append(folded, proj)
+		}
+	}
+	return folded
+}
+
+// foldSkills replaces the previous skill categories imported again and
+// keeps the others (e.g. the LinkedIn "Skills" category on a GitHub sync)
+func foldSkills(previous, imported []models.SkillCategory) []models.SkillCategory {
+	folded := append([]models.SkillCategory(nil), previous...)
+	for _, category := range imported {
+		found := false
+		for i := range folded {
+			if strings.EqualFold(folded[i].Category, category.Category) {
+				folded[i] = category
+				found = true
+				break
+			}
+		}
+		if !found {
+			folded = append(folded, category)
+		}
+	}
+	return folded
+}
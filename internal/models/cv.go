@@ -15,6 +15,11 @@
 
 package models
 
+import (
+	"strings"
+	"time"
+)
+
 // CV represents the complete structure of a resume
 type CV struct {
 	Personal       Personal        `yaml:"personal"`
@@ -42,6 +47,11 @@ type Personal struct {
 	Photo          string `yaml:"photo"`
 	PhotoGrayscale bool   `yaml:"photoGrayscale"` // Black and white filter
 	PhotoShape     string `yaml:"photoShape"`     // "round" (default) or "square"
+	// QRCode adds a QR code to the CV: "vcard" for the contact card, or the
+	// URL of the online CV
+	QRCode string `yaml:"qrCode,omitempty"`
+	// Private lists the fields (phone, email...) left out of redacted output
+	Private []string `yaml:"private,omitempty"`
 }
 
 // Experience represents a work experience
@@ -53,6 +63,7 @@ type Experience struct {
 	EndDate     string   `yaml:"endDate"`
 	Description string   `yaml:"description"`
 	Highlights  []string `yaml:"highlights"`
+	Private     bool     `yaml:"private,omitempty"` // Left out of redacted output
 }
 
 // Education represents an educational background
@@ -64,6 +75,7 @@ type Education struct {
 	StartDate   string `yaml:"startDate"`
 	EndDate     string `yaml:"endDate"`
 	Description string `yaml:"description"`
+	Private     bool   `yaml:"private,omitempty"` // Left out of redacted output
 }
 
 // SkillCategory represents a skill category
@@ -80,9 +92,10 @@ type Language struct {
 
 // Certification represents a certification
 type Certification struct {
-	Name   string `yaml:"name"`
-	Issuer string `yaml:"issuer"`
-	Date   string `yaml:"date"`
+	Name    string `yaml:"name"`
+	Issuer  string `yaml:"issuer"`
+	Date    string `yaml:"date"`
+	Private bool   `yaml:"private,omitempty"` // Left out of redacted output
 }
 
 // Project represents a personal project
@@ -91,6 +104,9 @@ type Project struct {
 	Description  string   `yaml:"description"`
 	URL          string   `yaml:"url"`
 	Technologies []string `yaml:"technologies"`
+	Stars        int      `yaml:"stars,omitempty"` // Shown next to the name when set
+	Forks        int      `yaml:"forks,omitempty"`
+	Private      bool     `yaml:"private,omitempty"` // Left out of redacted output
 }
 
 // FullName returns the full name
@@ -105,3 +121,43 @@ func FormatDate(date string) string {
 	}
 	return date
 }
+
+// NormalizeDate converts "09/2020", "9/2020", "2020-09" and "2020/09" to
+// "2020-09" so imported dates match hand-written ones. Other values are
+// returned trimmed.
+func NormalizeDate(date string) string {
+	date = strings.TrimSpace(date)
+	if parts := strings.Split(date, "/"); len(parts) == 2 {
+		year, month := parts[1], parts[0]
+		if len(parts[0]) == 4 {
+			year, month = parts[0], parts[1]
+		}
+		if len(month) == 1 {
+			month = "0" + month
+		}
+		return year + "-" + month
+	}
+	return date
+}
+
+// IsCurrent reports whether an end date means the position is ongoing
+func IsCurrent(endDate string) bool {
+	switch strings.ToLower(strings.TrimSpace(endDate)) {
+	case "", "present", "présent":
+		return true
+	}
+	return false
+}
+
+// ParseDate parses a YYYY-MM, MM/YYYY or YYYY date into the first and last
+// month it may denote: a year alone covers its twelve months
+func ParseDate(date string) (first, last time.Time, ok bool) {
+	date = NormalizeDate(date)
+	if t, err := time.Parse("2006-01", date); err == nil {
+		return t, t, true
+	}
+	if t, err := time.Parse("2006", date); err == nil {
+		return t, t.AddDate(0, 11, 0), true
+	}
+	return time.Time{}, time.Time{}, false
+}
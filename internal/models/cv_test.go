@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import "testing"
+
+func TestNormalizeDate(t *testing.T) {
+	for date, want := range map[string]string{
+		"2020-09":   "2020-09",
+		"09/2020":   "2020-09",
+		"9/2020":    "2020-09",
+		"2020/09":   "2020-09",
+		" 2020 ":    "2020",
+		"":          "",
+		"Fall 2020": "Fall 2020",
+	} {
+		if got := NormalizeDate(date); got != want {
+			t.Errorf("NormalizeDate(%q) = %q, want %q", date, got, want)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		date        string
+		first, last string // YYYY-MM, empty when the date does not parse
+	}{
+		{date: "2020-09", first: "2020-09", last: "2020-09"},
+		{date: "9/2020", first: "2020-09", last: "2020-09"},
+		{date: "2020", first: "2020-01", last: "2020-12"},
+		{date: "present"},
+		{date: "Fall 2020"},
+		{date: ""},
+	}
+	for _, tt := range tests {
+		first, last, ok := ParseDate(tt.date)
+		if ok != (tt.first != "") || (ok && (first.Format("2006-01") != tt.first || last.Format("2006-01") != tt.last)) {
+			t.Errorf("ParseDate(%q) = %s, %s, %v; want %s, %s", tt.date, first.Format("2006-01"), last.Format("2006-01"), ok, tt.first, tt.last)
+		}
+	}
+}
+
+func TestIsCurrent(t *testing.T) {
+	for date, want := range map[string]bool{
+		"":         true,
+		"present":  true,
+		"Présent ": true,
+		"PRESENT":  true,
+		"2020-09":  false,
+	} {
+		if got := IsCurrent(date); got != want {
+			t.Errorf("IsCurrent(%q) = %v, want %v", date, got, want)
+		}
+	}
+}
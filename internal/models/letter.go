@@ -0,0 +1,31 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+// Letter represents a cover letter, written next to the CV it goes with
+type Letter struct {
+	Recipient string `yaml:"recipient"` // e.g. "Jane Smith, Engineering Manager"
+	Company   string `yaml:"company"`
+	Address   string `yaml:"address"` // Multi-line postal address
+	Date      string `yaml:"date"`    // Defaults to today
+	Subject   string `yaml:"subject"`
+	Greeting  string `yaml:"greeting"` // Defaults to "Dear <recipient>,"
+	// Body holds the paragraphs. They are Go templates with access to the
+	// CV ({{.Personal.Title}}, {{(index .Experience 0).Company}}) and to
+	// the letter ({{.Letter.Company}}).
+	Body    []string `yaml:"body"`
+	Closing string   `yaml:"closing"` // Defaults to "Sincerely,"
+}
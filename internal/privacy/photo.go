@@ -0,0 +1,208 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package privacy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	jpegSignature = []byte{0xFF, 0xD8}
+	pngSignature  = []byte("\x89PNG\r\n\x1a\n")
+
+	exifHeader        = []byte("Exif\x00\x00")
+	xmpHeader         = []byte("http://ns.adobe.com/xap/1.0/\x00")
+	xmpExtendedHeader = []byte("http://ns.adobe.com/xmp/extension/\x00")
+)
+
+// errMalformed is returned for images whose structure cannot be followed
+var errMalformed = errors.New("malformed image")
+
+// StripLocation removes the location data of a JPEG or PNG photo: the GPS
+// tags of its EXIF data (the other tags, like the orientation, are kept),
+// and the XMP and IPTC blocks that may repeat them. Other formats are
+// returned unchanged.
+func StripLocation(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, jpegSignature):
+		return stripJPEG(data)
+	case bytes.HasPrefix(data, pngSignature):
+		return stripPNG(data)
+	}
+	return data, nil
+}
+
+// stripJPEG rewrites the metadata segments of a JPEG file, up to the start
+// of the image data
+func stripJPEG(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(jpegSignature)
+
+	pos := len(jpegSignature)
+	for {
+		if pos+4 > len(data) || data[pos] != 0xFF {
+			return nil, errMalformed
+		}
+		marker := data[pos+1]
+		if marker == 0xFF {
+			// Fill byte
+			pos++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			// Start of scan or end of image: the rest is image data
+			out.Write(data[pos:])
+			return out.Bytes(), nil
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, errMalformed
+		}
+		segment := data[pos:end]
+		payload := segment[4:]
+		pos = end
+
+		switch {
+		case marker == 0xE1 && bytes.HasPrefix(payload, exifHeader):
+			segment = bytes.Clone(segment)
+			if err := removeGPS(segment[4+len(exifHeader):]); err != nil {
+				return nil, err
+			}
+		case marker == 0xE1 && (bytes.HasPrefix(payload, xmpHeader) || bytes.HasPrefix(payload, xmpExtendedHeader)):
+			continue
+		case marker == 0xED:
+			// Photoshop resources, with the IPTC city and location fields
+			continue
+		}
+		out.Write(segment)
+	}
+}
+
+// stripPNG drops the eXIf chunk and the XMP text chunk of a PNG file
+func stripPNG(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(pngSignature)
+
+	pos := len(pngSignature)
+	for pos < len(data) {
+		if pos+12 > len(data) {
+			return nil, errMalformed
+		}
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, errMalformed
+		}
+		kind := string(data[pos+4 : pos+8])
+		chunk := data[pos+8 : pos+8+length]
+
+		drop := kind == "eXIf" || kind == "iTXt" && bytes.HasPrefix(chunk, []byte("XML:com.adobe.xmp\x00"))
+		if !drop {
+			out.Write(data[pos:end])
+		}
+		pos = end
+	}
+	return out.Bytes(), nil
+}
+
+// gpsInfoTag is the IFD0 tag pointing to the GPS IFD
+const gpsInfoTag = 0x8825
+
+// removeGPS empties, in place, the GPS IFD of a TIFF structure (the body of
+// an EXIF block): its values are zeroed and its entry count set to 0
+func removeGPS(tiff []byte) error {
+	if len(tiff) < 8 {
+		return errMalformed
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return errMalformed
+	}
+
+	entries, err := ifdEntries(tiff, order, int(order.Uint32(tiff[4:])))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if order.Uint16(entry) != gpsInfoTag {
+			continue
+		}
+		offset := int(order.Uint32(entry[8:]))
+		gps, err := ifdEntries(tiff, order, offset)
+		if err != nil {
+			return err
+		}
+		for _, field := range gps {
+			if size := valueSize(order.Uint16(field[2:]), order.Uint32(field[4:])); size > 4 {
+				start := int(order.Uint32(field[8:]))
+				if start >= 0 && size <= len(tiff) && start <= len(tiff)-size {
+					clear(tiff[start : start+size])
+				}
+			}
+			clear(field)
+		}
+		order.PutUint16(tiff[offset:], 0)
+	}
+	return nil
+}
+
+// ifdEntries returns the 12-byte entries of the IFD at offset
+func ifdEntries(tiff []byte, order binary.ByteOrder, offset int) ([][]byte, error) {
+	if offset < 8 || offset+2 > len(tiff) {
+		return nil, errMalformed
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	start := offset + 2
+	if start+count*12 > len(tiff) {
+		return nil, errMalformed
+	}
+	entries := make([][]byte, count)
+	for i := range entries {
+		entries[i] = tiff[start+i*12 : start+(i+1)*12]
+	}
+	return entries, nil
+}
+
+// valueSize returns the size in bytes of the value of an IFD entry, or -1
+// for unknown types
+func valueSize(kind uint16, count uint32) int {
+	var size int
+	switch kind {
+	case 1, 2, 6, 7: // BYTE, ASCII, SBYTE, UNDEFINED
+		size = 1
+	case 3, 8: // SHORT, SSHORT
+		size = 2
+	case 4, 9, 11: // LONG, SLONG, FLOAT
+		size = 4
+	case 5, 10, 12: // RATIONAL, SRATIONAL, DOUBLE
+		size = 8
+	default:
+		return -1
+	}
+	if uint64(count)*uint64(size) > 1<<30 {
+		return -1
+	}
+	return size * int(count)
+}
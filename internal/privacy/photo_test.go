@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package privacy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// exifTIFF builds a TIFF structure with an orientation tag and a GPS IFD
+// holding a latitude (a rational array stored out of the entry)
+func exifTIFF(order interface {
+	binary.ByteOrder
+	binary.AppendByteOrder
+}) []byte {
+	tiff := make([]byte, 8, 96)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+
+	// entry appends an IFD entry, its value left-aligned in the last field
+	entry := func(tag, kind uint16, count uint32, value []byte) {
+		tiff = order.AppendUint16(tiff, tag)
+		tiff = order.AppendUint16(tiff, kind)
+		tiff = order.AppendUint32(tiff, count)
+		tiff = append(tiff, value...)
+		tiff = append(tiff, make([]byte, 4-len(value))...)
+	}
+
+	// IFD0 at 8: orientation and GPS pointer
+	tiff = order.AppendUint16(tiff, 2)
+	entry(0x0112, 3, 1, order.AppendUint16(nil, 6))
+	entry(gpsInfoTag, 4, 1, order.AppendUint32(nil, 38))
+	tiff = order.AppendUint32(tiff, 0)
+
+	// GPS IFD at 38: latitude reference and latitude
+	tiff = order.AppendUint16(tiff, 2)
+	entry(0x0001, 2, 2, []byte("N\x00"))
+	entry(0x0002, 5, 3, order.AppendUint32(nil, 68))
+	tiff = order.AppendUint32(tiff, 0)
+
+	// Latitude values at 68: 48/1, 51/1, 24/1
+	for _, v := range []uint32{48, 1, 51, 1, 24, 1} {
+		tiff = order.AppendUint32(tiff, v)
+	}
+	return tiff
+}
+
+// jpegSegment builds a JPEG marker segment
+func jpegSegment(marker byte, payload []byte) []byte {
+	segment := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// pngChunk builds a PNG chunk, with a dummy CRC
+func pngChunk(kind string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, kind...)
+	chunk = append(chunk, data...)
+	return append(chunk, 0, 0, 0, 0)
+}
+
+func TestRemoveGPS(t *testing.T) {
+	orders := []interface {
+		binary.ByteOrder
+		binary.AppendByteOrder
+	}{binary.LittleEndian, binary.BigEndian}
+	for _, order := range orders {
+		t.Run(order.String(), func(t *testing.T) {
+			tiff := exifTIFF(order)
+			if err := removeGPS(tiff); err != nil {
+				t.Fatalf("removeGPS: %v", err)
+			}
+
+			if got := order.Uint16(tiff[38:]); got != 0 {
+				t.Errorf("GPS IFD has %d entries, want 0", got)
+			}
+			if !bytes.Equal(tiff[40:64], make([]byte, 24)) {
+				t.Errorf("GPS entries not cleared: % x", tiff[40:64])
+			}
+			if !bytes.Equal(tiff[68:92], make([]byte, 24)) {
+				t.Errorf("GPS latitude not cleared: % x", tiff[68:92])
+			}
+			if got := order.Uint16(tiff[10:]); got != 0x0112 {
+				t.Errorf("orientation tag = %#x, want it kept", got)
+			}
+		})
+	}
+}
+
+func TestRemoveGPSMalformed(t *testing.T) {
+	tests := map[string][]byte{
+		"short":       []byte("II*\x00"),
+		"byte order":  append([]byte("XX*\x00\x08\x00\x00\x00"), make([]byte, 8)...),
+		"IFD offset":  []byte("II*\x00\xff\x00\x00\x00\x00\x00"),
+		"entry count": []byte("II*\x00\x08\x00\x00\x00\x05\x00"),
+		"GPS pointer": func() []byte {
+			tiff := exifTIFF(binary.LittleEndian)
+			binary.LittleEndian.PutUint32(tiff[30:], 500)
+			return tiff
+		}(),
+	}
+	for name, tiff := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := removeGPS(tiff); !errors.Is(err, errMalformed) {
+				t.Errorf("removeGPS = %v, want %v", err, errMalformed)
+			}
+		})
+	}
+}
+
+func TestStripJPEG(t *testing.T) {
+	exif := append(bytes.Clone(exifHeader), exifTIFF(binary.BigEndian)...)
+	xmp := append(bytes.Clone(xmpHeader), "<x:xmpmeta>Paris</x:xmpmeta>"...)
+	iptc := []byte("Photoshop 3.0\x00city")
+	app0 := []byte("JFIF\x00\x01\x02")
+	scan := []byte{0xFF, 0xDA, 0x00, 0x02, 0x12, 0x34, 0xFF, 0xD9}
+
+	var data []byte
+	data = append(data, jpegSignature...)
+	data = append(data, jpegSegment(0xE0, app0)...)
+	data = append(data, jpegSegment(0xE1, exif)...)
+	data = append(data, 0xFF) // fill byte
+	data = append(data, jpegSegment(0xE1, xmp)...)
+	data = append(data, jpegSegment(0xED, iptc)...)
+	data = append(data, scan...)
+	original := bytes.Clone(data)
+
+	got, err := StripLocation(data)
+	if err != nil {
+		t.Fatalf("StripLocation: %v", err)
+	}
+	if !bytes.Equal(data, original) {
+		t.Error("StripLocation modified its input")
+	}
+
+	stripped := bytes.Clone(exif)
+	if err := removeGPS(stripped[len(exifHeader):]); err != nil {
+		t.Fatal(err)
+	}
+	var want []byte
+	want = append(want, jpegSignature...)
+	want = append(want, jpegSegment(0xE0, app0)...)
+	want = append(want, jpegSegment(0xE1, stripped)...)
+	want = append(want, scan...)
+	if !bytes.Equal(got, want) {
+		t.Errorf("StripLocation =\n% x\nwant\n% x", got, want)
+	}
+}
+
+func TestStripJPEGMalformed(t *testing.T) {
+	tests := map[string][]byte{
+		"truncated":      {0xFF, 0xD8, 0xFF},
+		"no marker":      {0xFF, 0xD8, 0x00, 0xE0, 0x00, 0x04},
+		"short length":   {0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x01, 0xFF, 0xDA},
+		"segment length": {0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x40, 0x00, 0x00},
+		"exif": append([]byte{0xFF, 0xD8},
+			jpegSegment(0xE1, append(bytes.Clone(exifHeader), "II*\x00"...))...),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := StripLocation(data); !errors.Is(err, errMalformed) {
+				t.Errorf("StripLocation = %v, want %v", err, errMalformed)
+			}
+		})
+	}
+}
+
+func TestStripPNG(t *testing.T) {
+	header := pngChunk("IHDR", make([]byte, 13))
+	text := pngChunk("iTXt", []byte("Author\x00\x00\x00\x00\x00Jane"))
+	end := pngChunk("IEND", nil)
+
+	var data []byte
+	data = append(data, pngSignature...)
+	data = append(data, header...)
+	data = append(data, pngChunk("eXIf", exifTIFF(binary.LittleEndian))...)
+	data = append(data, pngChunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00<x:xmpmeta/>"))...)
+	data = append(data, text...)
+	data = append(data, end...)
+
+	got, err := StripLocation(data)
+	if err != nil {
+		t.Fatalf("StripLocation: %v", err)
+	}
+	var want []byte
+	want = append(want, pngSignature...)
+	want = append(want, header...)
+	want = append(want, text...)
+	want = append(want, end...)
+	if !bytes.Equal(got, want) {
+		t.Errorf("StripLocation =\n% x\nwant\n% x", got, want)
+	}
+
+	for _, truncated := range [][]byte{data[:len(data)-3], data[:len(pngSignature)+20]} {
+		if _, err := StripLocation(truncated); !errors.Is(err, errMalformed) {
+			t.Errorf("StripLocation(%d bytes) = %v, want %v", len(truncated), err, errMalformed)
+		}
+	}
+}
+
+func TestStripOtherFormats(t *testing.T) {
+	data := []byte("GIF89a\x01\x00\x01\x00")
+	got, err := StripLocation(data)
+	if err != nil || !bytes.Equal(got, data) {
+		t.Errorf("StripLocation = %q, %v; want the data unchanged", got, err)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package privacy removes personal details from a CV before it is
+// published: redacted contact fields, entries marked private and the
+// location data of photos.
+package privacy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"resumectl/internal/models"
+)
+
+// Private is the redaction name of the fields and entries marked private in
+// the CV. It is implied by any other redaction.
+const Private = "private"
+
+// Fields are the personal fields that can be redacted
+var Fields = []string{"email", "phone", "location", "linkedin", "github", "website", "photo"}
+
+// ParseFields splits a comma-separated list of fields to redact and checks
+// them against Fields and Private
+func ParseFields(list string) ([]string, error) {
+	var fields []string
+	for _, field := range strings.Split(list, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		if field != Private && !slices.Contains(Fields, field) {
+			return nil, fmt.Errorf("unknown field %q to redact (use %s or %s)", field, strings.Join(Fields, ", "), Private)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// Check reports the unknown field names listed in personal.private
+func Check(cv *models.CV) error {
+	for _, field := range cv.Personal.Private {
+		if !slices.Contains(Fields, strings.ToLower(field)) {
+			return fmt.Errorf("personal.private: unknown field %q (use %s)", field, strings.Join(Fields, ", "))
+		}
+	}
+	return nil
+}
+
+// Redact removes the given personal fields from cv, along with the fields
+// listed in personal.private and the entries marked private: true. It does
+// nothing when fields is empty.
+func Redact(cv *models.CV, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+
+	p := &cv.Personal
+	for _, field := range slices.Concat(fields, p.Private) {
+		switch strings.ToLower(field) {
+		case "email":
+			p.Email = ""
+		case "phone":
+			p.Phone = ""
+		case "location":
+			p.Location = ""
+		case "linkedin":
+			p.LinkedIn = ""
+		case "github":
+			p.GitHub = ""
+		case "website":
+			p.Website = ""
+		case "photo":
+			p.Photo = ""
+		}
+	}
+	p.Private = nil
+
+	cv.Experience = public(cv.Experience, func(e models.Experience) bool { return e.Private })
+	cv.Education = public(cv.Education, func(e models.Education) bool { return e.Private })
+	cv.Certifications = public(cv.Certifications, func(c models.Certification) bool { return c.Private })
+	cv.Projects = public(cv.Projects, func(p models.Project) bool { return p.Private })
+}
+
+// public returns the entries that are not private
+func public[T any](entries []T, private func(T) bool) []T {
+	var result []T
+	for _, entry := range entries {
+		if !private(entry) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
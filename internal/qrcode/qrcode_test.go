@@ -0,0 +1,414 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package qrcode
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+var levelNames = [...]string{Low: "L", Medium: "M", Quartile: "Q", High: "H"}
+
+// formatStrings are the 15-bit format information strings of ISO/IEC 18004
+// Table C.1, masked with 101010000010010, by level and mask
+var formatStrings = [4][8]string{
+	Low:      {"111011111000100", "111001011110011", "111110110101010", "111100010011101", "110011000101111", "110001100011000", "110110001000001", "110100101110110"},
+	Medium:   {"101010000010010", "101000100100101", "101111001111100", "101101101001011", "100010111111001", "100000011001110", "100111110010111", "100101010100000"},
+	Quartile: {"011010101011111", "011000001101000", "011111100110001", "011101000000110", "010010010110100", "010000110000011", "010111011011010", "010101111101101"},
+	High:     {"001011010001001", "001001110111110", "001110011100111", "001100111010000", "000011101100010", "000001001010101", "000110100001100", "000100000111011"},
+}
+
+// TestCapacity checks the version chosen at the byte mode capacities of
+// ISO/IEC 18004 Table 7: the largest input of a version and one more byte
+func TestCapacity(t *testing.T) {
+	tests := []struct {
+		version int
+		level   Level
+		bytes   int
+	}{
+		{1, Low, 17}, {1, Medium, 14}, {1, Quartile, 11}, {1, High, 7},
+		{2, Medium, 26}, {7, Medium, 122}, {9, Medium, 180},
+		{10, Medium, 213}, {10, High, 119},
+		{40, Low, 2953}, {40, Medium, 2331}, {40, Quartile, 1663}, {40, High, 1273},
+	}
+
+	for _, tt := range tests {
+		name := fmt.Sprintf("%d-%s", tt.version, levelNames[tt.level])
+		t.Run(name, func(t *testing.T) {
+			c, err := Encode(strings.Repeat("a", tt.bytes), tt.level)
+			if err != nil {
+				t.Fatalf("Encode(%d bytes): %v", tt.bytes, err)
+			}
+			if c.Version != tt.version || c.Size != 4*tt.version+17 {
+				t.Errorf("Encode(%d bytes) = version %d, size %d; want version %d", tt.bytes, c.Version, c.Size, tt.version)
+			}
+
+			c, err = Encode(strings.Repeat("a", tt.bytes+1), tt.level)
+			if tt.version == 40 {
+				if err == nil {
+					t.Errorf("Encode(%d bytes) succeeded beyond version 40", tt.bytes+1)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Encode(%d bytes): %v", tt.bytes+1, err)
+			}
+			if c.Version != tt.version+1 {
+				t.Errorf("Encode(%d bytes) = version %d, want version %d", tt.bytes+1, c.Version, tt.version+1)
+			}
+		})
+	}
+}
+
+// TestReedSolomon checks the error correction of the 1-M "HELLO WORLD"
+// example of the Thonky QR code tutorial
+func TestReedSolomon(t *testing.T) {
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := rsRemainder(data, rsDivisor(len(want))); !bytes.Equal(got, want) {
+		t.Errorf("rsRemainder = %v, want %v", got, want)
+	}
+}
+
+func TestFormatInformation(t *testing.T) {
+	for level := Low; level <= High; level++ {
+		for mask := 0; mask < 8; mask++ {
+			c := &Code{Version: 1, Size: 21, modules: grid(21), function: grid(21)}
+			c.drawFormat(level, mask)
+
+			first, second := formatInformation(c)
+			if first != formatStrings[level][mask] || second != first {
+				t.Errorf("format of %s mask %d = %s and %s, want %s", levelNames[level], mask, first, second, formatStrings[level][mask])
+			}
+		}
+	}
+}
+
+// TestVersionInformation checks the version information of ISO/IEC 18004
+// Table D.1
+func TestVersionInformation(t *testing.T) {
+	tests := map[int]string{
+		7:  "000111110010010100",
+		8:  "001000010110111100",
+		21: "010101011010000011",
+		40: "101000110001101001",
+	}
+
+	for version, want := range tests {
+		size := 4*version + 17
+		c := &Code{Version: version, Size: size, modules: grid(size), function: grid(size)}
+		c.drawVersion()
+
+		// Bit 17 first, in the top-right block read by columns and in the
+		// bottom-left block read by rows
+		var topRight, bottomLeft strings.Builder
+		for i := 17; i >= 0; i-- {
+			topRight.WriteString(bit(c.Dark(size-11+i%3, i/3)))
+			bottomLeft.WriteString(bit(c.Dark(i/3, size-11+i%3)))
+		}
+		if topRight.String() != want || bottomLeft.String() != want {
+			t.Errorf("version %d information = %s and %s, want %s", version, topRight.String(), bottomLeft.String(), want)
+		}
+	}
+}
+
+// TestRoundTrip decodes codes of every level and of versions with one to
+// many blocks, checking the placement, the masking and the error correction
+func TestRoundTrip(t *testing.T) {
+	inputs := []string{
+		"",
+		"https://jane.example.com/",
+		strings.Repeat("BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Jane Doe\r\nEND:VCARD\r\n", 4),
+		strings.Repeat("résumé ", 60),
+	}
+
+	for _, input := range inputs {
+		for level := Low; level <= High; level++ {
+			c, err := Encode(input, level)
+			if err != nil {
+				t.Fatalf("Encode(%d bytes, %s): %v", len(input), levelNames[level], err)
+			}
+			name := fmt.Sprintf("%d bytes %d-%s", len(input), c.Version, levelNames[level])
+
+			got, err := decode(c)
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+			} else if got != input {
+				t.Errorf("%s: decoded %q, want %q", name, got, input)
+			}
+		}
+	}
+}
+
+// TestGolden compares whole codes, version and mask included, with the
+// matrices in testdata, rewritten with -update
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		level Level
+	}{
+		{"url-M", "https://jane.example.com/", Medium},
+		{"vcard-L", "BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Jane Doe\r\nN:Doe;Jane;;;\r\nEMAIL:jane@example.com\r\nTEL;VALUE=uri:tel:+33-6-12-34-56-78\r\nURL:https://jane.example.com\r\nEND:VCARD\r\n", Low},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Encode(tt.data, tt.level)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+
+			first, _ := formatInformation(c)
+			var got bytes.Buffer
+			fmt.Fprintf(&got, "version %d, level %s, mask %d\n", c.Version, levelNames[tt.level], formatMask(first))
+			for y := 0; y < c.Size; y++ {
+				for x := 0; x < c.Size; x++ {
+					got.WriteString(map[bool]string{true: "#", false: "."}[c.Dark(x, y)])
+				}
+				got.WriteString("\n")
+			}
+
+			path := filepath.Join("testdata", tt.name+".txt")
+			if *update {
+				if err := os.WriteFile(path, got.Bytes(), 0644); err != nil {
+					t.Fatalf("writing golden file: %v", err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("reading golden file (run with -update to create it): %v", err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", tt.name, got.Bytes(), want)
+			}
+		})
+	}
+}
+
+func TestSVG(t *testing.T) {
+	c, err := Encode("https://jane.example.com/", Medium)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svg := c.SVG()
+
+	size := c.Size + 2*quietZone
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, fmt.Sprintf(`viewBox="0 0 %d %d"`, size, size)) {
+		t.Errorf("SVG has no viewBox of %d modules: %s", size, svg[:min(len(svg), 200)])
+	}
+}
+
+func bit(dark bool) string {
+	if dark {
+		return "1"
+	}
+	return "0"
+}
+
+// formatInformation reads the two copies of the format information, most
+// significant bit first
+func formatInformation(c *Code) (first, second string) {
+	// Positions of bits 14 to 0 around the top-left finder pattern...
+	firstCopy := [15][2]int{
+		{0, 8}, {1, 8}, {2, 8}, {3, 8}, {4, 8}, {5, 8}, {7, 8}, {8, 8},
+		{8, 7}, {8, 5}, {8, 4}, {8, 3}, {8, 2}, {8, 1}, {8, 0},
+	}
+	// ...and split between the bottom-left and top-right ones
+	var secondCopy [15][2]int
+	for i := 0; i < 7; i++ {
+		secondCopy[i] = [2]int{8, c.Size - 1 - i}
+	}
+	for i := 7; i < 15; i++ {
+		secondCopy[i] = [2]int{c.Size - 15 + i, 8}
+	}
+
+	var a, b strings.Builder
+	for i := 0; i < 15; i++ {
+		a.WriteString(bit(c.Dark(firstCopy[i][0], firstCopy[i][1])))
+		b.WriteString(bit(c.Dark(secondCopy[i][0], secondCopy[i][1])))
+	}
+	return a.String(), b.String()
+}
+
+// formatMask returns the mask of a format information string, or -1
+func formatMask(format string) int {
+	for _, masks := range formatStrings {
+		for mask, s := range masks {
+			if s == format {
+				return mask
+			}
+		}
+	}
+	return -1
+}
+
+// decode reads a code the way a scanner does: format information, mask,
+// codewords in zigzag order, blocks, error correction check and byte mode
+// data
+func decode(c *Code) (string, error) {
+	first, second := formatInformation(c)
+	if first != second {
+		return "", fmt.Errorf("format copies differ: %s and %s", first, second)
+	}
+	level, mask := Level(-1), -1
+	for l, masks := range formatStrings {
+		for m, s := range masks {
+			if s == first {
+				level, mask = Level(l), m
+			}
+		}
+	}
+	if mask < 0 {
+		return "", fmt.Errorf("invalid format information %s", first)
+	}
+	if !c.Dark(8, c.Size-8) {
+		return "", fmt.Errorf("dark module missing")
+	}
+
+	masked := []func(x, y int) bool{
+		func(x, y int) bool { return (y+x)%2 == 0 },
+		func(x, y int) bool { return y%2 == 0 },
+		func(x, y int) bool { return x%3 == 0 },
+		func(x, y int) bool { return (y+x)%3 == 0 },
+		func(x, y int) bool { return (y/2+x/3)%2 == 0 },
+		func(x, y int) bool { return (y*x)%2+(y*x)%3 == 0 },
+		func(x, y int) bool { return ((y*x)%2+(y*x)%3)%2 == 0 },
+		func(x, y int) bool { return ((y+x)%2+(y*x)%3)%2 == 0 },
+	}[mask]
+
+	// Codewords, from the bottom-right corner up and down two-module
+	// columns, right module first, skipping the vertical timing pattern
+	var bits []bool
+	upward := true
+	for right := c.Size - 1; right > 0; right -= 2 {
+		if right == 6 {
+			right--
+		}
+		for i := 0; i < c.Size; i++ {
+			y := i
+			if upward {
+				y = c.Size - 1 - i
+			}
+			for _, x := range []int{right, right - 1} {
+				if !c.function[y][x] {
+					bits = append(bits, c.Dark(x, y) != masked(x, y))
+				}
+			}
+		}
+		upward = !upward
+	}
+	codewords := make([]byte, len(bits)/8)
+	for i := range codewords {
+		for _, b := range bits[8*i : 8*i+8] {
+			codewords[i] <<= 1
+			if b {
+				codewords[i] |= 1
+			}
+		}
+	}
+
+	// Blocks: the short ones first, data interleaved then error correction
+	numBlocks, eccLen := blocks[level][c.Version], eccPerBlock[level][c.Version]
+	numLong := len(codewords) % numBlocks
+	shortData := len(codewords)/numBlocks - eccLen
+	blockData := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < shortData+1; i++ {
+		for b := range blockData {
+			if i < shortData || b >= numBlocks-numLong {
+				blockData[b] = append(blockData[b], codewords[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	for b := range blockData {
+		block := append([]byte(nil), blockData[b]...)
+		for i := 0; i < eccLen; i++ {
+			block = append(block, codewords[k+i*numBlocks+b])
+		}
+		if err := checkSyndromes(block, eccLen); err != nil {
+			return "", fmt.Errorf("block %d: %w", b, err)
+		}
+		data = append(data, blockData[b]...)
+	}
+
+	// Byte mode segment, terminator and padding
+	reader := bitReader{data: data}
+	if mode := reader.read(4); mode != 0b0100 {
+		return "", fmt.Errorf("mode %04b, want byte mode", mode)
+	}
+	countLen := 8
+	if c.Version >= 10 {
+		countLen = 16
+	}
+	text := make([]byte, reader.read(countLen))
+	for i := range text {
+		text[i] = byte(reader.read(8))
+	}
+	if reader.pos+4 <= 8*len(data) && reader.read(4) != 0 {
+		return "", fmt.Errorf("missing terminator")
+	}
+	reader.pos = (reader.pos + 7) / 8 * 8
+	for pad := 0xEC; reader.pos < 8*len(data); pad ^= 0xEC ^ 0x11 {
+		if got := reader.read(8); got != pad {
+			return "", fmt.Errorf("padding %#x, want %#x", got, pad)
+		}
+	}
+	return string(text), nil
+}
+
+// checkSyndromes checks that a block with its error correction codewords
+// is a multiple of the generator polynomial: it evaluates to zero at its
+// roots 2^0 to 2^(eccLen-1)
+func checkSyndromes(block []byte, eccLen int) error {
+	root := byte(1)
+	for i := 0; i < eccLen; i++ {
+		var value byte
+		for _, coef := range block {
+			value = gfMultiply(value, root) ^ coef
+		}
+		if value != 0 {
+			return fmt.Errorf("syndrome %d is %#x", i, value)
+		}
+		root = gfMultiply(root, 2)
+	}
+	return nil
+}
+
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) read(n int) int {
+	v := 0
+	for i := 0; i < n; i++ {
+		v = v<<1 | int(r.data[r.pos/8]>>(7-r.pos%8)&1)
+		r.pos++
+	}
+	return v
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package site
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"unicode"
+
+	"resumectl/internal/models"
+	"resumectl/internal/templates"
+)
+
+// navStyle is added to the CV page for the site navigation
+const navStyle = `<style>
+.site-nav { display: flex; gap: 16px; justify-content: flex-end; max-width: 210mm; margin: 0 auto; padding: 8px 0; font-size: 0.9em; }
+.site-nav a { color: var(--primary-color); text-decoration: none; }
+.site-nav a:hover { text-decoration: underline; }
+@media print { .site-nav { display: none; } }
+</style>`
+
+// nav returns the navigation bar of a page: projects and downloads
+func (b *builder) nav(page string) string {
+	root := relativeRoot(page)
+
+	var links []string
+	if page != "" {
+		links = append(links, fmt.Sprintf(`<a href="%s">CV</a>`, root))
+	}
+	if len(b.cv.Projects) > 0 && page != "projects/" {
+		links = append(links, fmt.Sprintf(`<a href="%sprojects/">Projects</a>`, root))
+	}
+	if b.opts.PDF {
+		links = append(links, fmt.Sprintf(`<a href="%scv.pdf" download>PDF</a>`, root))
+	}
+	if b.opts.DOCX {
+		links = append(links, fmt.Sprintf(`<a href="%scv.docx" download>DOCX</a>`, root))
+	}
+	if len(links) == 0 {
+		return ""
+	}
+	return navStyle + "\n" + `<nav class="site-nav">` + strings.Join(links, " ") + "</nav>"
+}
+
+// projects writes projects/index.html and a page per project
+func (b *builder) projects() error {
+	if len(b.cv.Projects) == 0 {
+		return nil
+	}
+
+	css, err := templates.GetThemeCSSWithColor(b.gen.GetTheme(), b.opts.Color)
+	if err != nil {
+		return err
+	}
+
+	paths := projectPaths(b.cv.Projects)
+	var items []projectItem
+	for i, proj := range b.cv.Projects {
+		items = append(items, projectItem{Project: proj, Link: strings.TrimPrefix(paths[i], "projects/")})
+	}
+
+	pages := []projectPage{{
+		Path:        "projects/",
+		Title:       "Projects",
+		Description: fmt.Sprintf("Projects of %s", b.cv.Personal.FullName()),
+		Projects:    items,
+	}}
+	for i, proj := range b.cv.Projects {
+		pages = append(pages, projectPage{
+			Path:        paths[i],
+			Title:       proj.Name,
+			Description: proj.Description,
+			Project:     &b.cv.Projects[i],
+		})
+	}
+
+	for _, page := range pages {
+		head := b.metadata(page.Path, page.Title+" - "+b.cv.Personal.FullName(), page.Description, false)
+		page.Personal = b.cv.Personal
+		page.CSS = template.CSS(css)
+		page.Head = template.HTML(head)
+		page.Nav = template.HTML(b.nav(page.Path))
+		page.Root = relativeRoot(page.Path)
+
+		var buf bytes.Buffer
+		if err := projectTemplate.Execute(&buf, page); err != nil {
+			return fmt.Errorf("error rendering %s: %w", page.Path, err)
+		}
+		if err := b.write(page.Path+"index.html", buf.Bytes()); err != nil {
+			return err
+		}
+		b.pages = append(b.pages, page.Path)
+	}
+	return nil
+}
+
+// projectPage is the data of a project page, or of the project list when
+// Project is nil
+type projectPage struct {
+	Path        string
+	Title       string
+	Description string
+	Project     *models.Project
+	Projects    []projectItem
+
+	Personal models.Personal
+	CSS      template.CSS
+	Head     template.HTML
+	Nav      template.HTML
+	Root     string
+}
+
+type projectItem struct {
+	models.Project
+	Link string
+}
+
+// projectPaths returns the page path of each project, from its name made
+// URL-friendly, with a numeric suffix for duplicates
+func projectPaths(projects []models.Project) []string {
+	used := make(map[string]int)
+	paths := make([]string, len(projects))
+	for i, proj := range projects {
+		slug := slugify(proj.Name)
+		if slug == "" {
+			slug = "project"
+		}
+		used[slug]++
+		if n := used[slug]; n > 1 {
+			slug = fmt.Sprintf("%s-%d", slug, n)
+		}
+		paths[i] = "projects/" + slug + "/"
+	}
+	return paths
+}
+
+// slugify lowercases a name and replaces everything but letters and digits
+// with dashes
+func slugify(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r < unicode.MaxASCII && unicode.IsLetter(r)) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "-")
+}
+
+// externalURL adds https:// to URLs written without a scheme, as the CV
+// template does for profile links
+func externalURL(u string) string {
+	if strings.Contains(u, "://") {
+		return u
+	}
+	return "https://" + u
+}
+
+var projectTemplate = template.Must(template.New("project").Funcs(template.FuncMap{"externalURL": externalURL}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}} - {{.Personal.FullName}}</title>
+    <style>
+{{.CSS}}
+    </style>
+{{.Head}}</head>
+<body>
+{{.Nav}}
+    <div class="container">
+        <header class="header">
+            <div class="header-content">
+                <div class="header-main">
+                    <h1><a href="{{.Root}}" style="color: inherit; text-decoration: none;">{{.Personal.FullName}}</a></h1>
+                    <div class="title">{{.Personal.Title}}</div>
+                </div>
+            </div>
+        </header>
+        <div class="left-column">
+            <section class="section">
+                <h2 class="section-title">{{.Title}}</h2>
+                {{- with .Project}}
+                <div class="project-item">
+                    {{if or .Stars .Forks}}<div class="project-stats">{{if .Stars}}★ {{.Stars}}{{end}}{{if and .Stars .Forks}} · {{end}}{{if .Forks}}⑂ {{.Forks}}{{end}}</div>{{end}}
+                    <p class="summary">{{.Description}}</p>
+                    {{if .Technologies}}
+                    <div class="skill-tags" style="margin-top: 12px;">
+                        {{range .Technologies}}<span class="skill-tag">{{.}}</span>{{end}}
+                    </div>
+                    {{end}}
+                    {{if .URL}}<p style="margin-top: 16px;"><a href="{{externalURL .URL}}">{{.URL}}</a></p>{{end}}
+                </div>
+                {{- else}}
+                {{range .Projects}}
+                <div class="project-item">
+                    <div class="project-name"><a href="{{.Link}}" style="color: inherit;">{{.Name}}</a>{{if or .Stars .Forks}} <span class="project-stats">{{if .Stars}}★ {{.Stars}}{{end}}{{if and .Stars .Forks}} · {{end}}{{if .Forks}}⑂ {{.Forks}}{{end}}</span>{{end}}</div>
+                    <p class="project-description">{{.Description}}</p>
+                    {{if .Technologies}}<div class="project-tech">{{range $i, $t := .Technologies}}{{if $i}}, {{end}}{{$t}}{{end}}</div>{{end}}
+                </div>
+                {{end}}
+                {{- end}}
+            </section>
+        </div>
+    </div>
+</body>
+</html>
+`))
@@ -0,0 +1,301 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package site builds a small static website from a CV: the themed CV as
+// index page, a page per project, downloadable PDF and DOCX versions,
+// Open Graph metadata, a favicon and a sitemap. The output can
+// be pushed as is to GitHub Pages.
+package site
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"resumectl/internal/docx"
+	"resumectl/internal/generator"
+	"resumectl/internal/models"
+	"resumectl/internal/privacy"
+	"resumectl/internal/templates"
+)
+
+// Options configures the website
+type Options struct {
+	Theme string
+	Color string
+	// BaseURL is the absolute URL the site is published at. It is needed
+	// for the sitemap and for absolute Open Graph and canonical links.
+	BaseURL string
+	PDF     bool // Render cv.pdf (needs one of the PDF tools of the generator)
+	DOCX    bool // Write cv.docx
+	// StructuredData embeds JSON-LD and microformats in the CV page
+	StructuredData bool
+	// Redact lists the personal fields left out of the site. The fields and
+	// entries marked private are always left out.
+	Redact         []string
+	ObfuscateEmail bool // Assemble the email address with JavaScript
+}
+
+// Build generates the website for the CV at dataPath into dir and returns
+// the paths of the files written, relative to dir
+func Build(dataPath, dir string, opts Options) ([]string, error) {
+	gen, err := generator.NewWithColor(dataPath, opts.Theme, opts.Color, dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating directory: %w", err)
+	}
+
+	b := &builder{gen: gen, cv: gen.GetCV(), dir: dir, opts: opts}
+	b.opts.BaseURL = strings.TrimSuffix(opts.BaseURL, "/")
+	if b.opts.BaseURL != "" {
+		b.opts.BaseURL += "/"
+	}
+	gen.SetStructuredData(opts.StructuredData)
+	gen.SetObfuscateEmail(opts.ObfuscateEmail)
+	gen.Redact(append([]string{privacy.Private}, opts.Redact...))
+	gen.SetPageURL(b.opts.BaseURL)
+
+	steps := []func() error{
+		b.index,
+		b.downloads,
+		b.projects,
+		b.favicon,
+		b.sitemap,
+		b.githubPages,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return b.files, err
+		}
+	}
+	return b.files, nil
+}
+
+// builder holds the state of a site generation
+type builder struct {
+	gen   *generator.Generator
+	cv    *models.CV
+	dir   string
+	opts  Options
+	files []string
+	pages []string // Pages for the sitemap, relative to the site root
+}
+
+// write saves a file of the site
+func (b *builder) write(name string, data []byte) error {
+	path := filepath.Join(b.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+	b.files = append(b.files, name)
+	return nil
+}
+
+// index renders the themed CV as index.html. The PDF is rendered from it
+// before the site navigation and metadata are added.
+func (b *builder) index() error {
+	indexPath := filepath.Join(b.dir, "index.html")
+	if err := b.gen.GenerateHTML(indexPath); err != nil {
+		return err
+	}
+	if b.cv.Personal.Photo != "" && !strings.Contains(b.cv.Personal.Photo, "://") {
+		b.files = append(b.files, b.cv.Personal.Photo)
+	}
+
+	if b.opts.PDF {
+		if err := b.gen.GeneratePDF(indexPath, filepath.Join(b.dir, "cv.pdf")); err != nil {
+			return err
+		}
+		b.files = append(b.files, "cv.pdf")
+	}
+
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		return err
+	}
+	html := string(data)
+
+	head := b.metadata("", fullTitle(b.cv.Personal), b.cv.Summary, true)
+	html = strings.Replace(html, "</head>", head+"</head>", 1)
+	html = bodyOpenPattern.ReplaceAllString(html, "${0}\n"+b.nav(""))
+
+	b.pages = append(b.pages, "")
+	return b.write("index.html", []byte(html))
+}
+
+var bodyOpenPattern = regexp.MustCompile(`<body[^>]*>`)
+
+// downloads writes the DOCX version of the CV
+func (b *builder) downloads() error {
+	if !b.opts.DOCX {
+		return nil
+	}
+	color := b.opts.Color
+	if color == "" {
+		color = themeColor(b.gen.GetTheme())
+	}
+	if err := docx.Write(b.cv, color, filepath.Join(b.dir, "cv.docx")); err != nil {
+		return fmt.Errorf("error writing cv.docx: %w", err)
+	}
+	b.files = append(b.files, "cv.docx")
+	return nil
+}
+
+// metadata returns the <head> additions of a page: description, canonical
+// link, favicon and Open Graph tags (profile ones for the CV). page is the
+// page path relative to the site root. The JSON-LD of the CV page comes
+// from the generator.
+func (b *builder) metadata(page, title, description string, person bool) string {
+	p := b.cv.Personal
+	root := relativeRoot(page)
+
+	var sb strings.Builder
+	tag := func(format string, args ...any) {
+		sb.WriteString("    ")
+		fmt.Fprintf(&sb, format, args...)
+		sb.WriteString("\n")
+	}
+
+	description = summarize(description)
+	if description != "" {
+		tag(`<meta name="description" content="%s">`, template.HTMLEscapeString(description))
+	}
+	tag(`<link rel="icon" href="%sfavicon.svg" type="image/svg+xml">`, root)
+	if b.opts.BaseURL != "" {
+		tag(`<link rel="canonical" href="%s">`, template.HTMLEscapeString(b.opts.BaseURL+page))
+		tag(`<meta property="og:url" content="%s">`, template.HTMLEscapeString(b.opts.BaseURL+page))
+	}
+	tag(`<meta property="og:type" content="%s">`, map[bool]string{true: "profile", false: "website"}[person])
+	tag(`<meta property="og:title" content="%s">`, template.HTMLEscapeString(title))
+	if description != "" {
+		tag(`<meta property="og:description" content="%s">`, template.HTMLEscapeString(description))
+	}
+	if person {
+		tag(`<meta property="profile:first_name" content="%s">`, template.HTMLEscapeString(p.FirstName))
+		tag(`<meta property="profile:last_name" content="%s">`, template.HTMLEscapeString(p.LastName))
+	}
+	// Open Graph images must be absolute
+	if p.Photo != "" && b.opts.BaseURL != "" && !strings.Contains(p.Photo, "://") {
+		tag(`<meta property="og:image" content="%s">`, template.HTMLEscapeString(b.opts.BaseURL+p.Photo))
+	}
+	tag(`<meta name="twitter:card" content="summary">`)
+
+	return sb.String()
+}
+
+// fullTitle returns the name and professional title
+func fullTitle(p models.Personal) string {
+	if p.Title == "" {
+		return p.FullName()
+	}
+	return p.FullName() + " - " + p.Title
+}
+
+// summarize shortens a text for meta descriptions
+func summarize(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	const limit = 200
+	if len(text) <= limit {
+		return text
+	}
+	cut := strings.LastIndex(text[:limit], " ")
+	if cut <= 0 {
+		cut = limit
+	}
+	return text[:cut] + "…"
+}
+
+// relativeRoot returns the path from page back to the site root
+func relativeRoot(page string) string {
+	depth := strings.Count(strings.TrimSuffix(page, "/"), "/")
+	if page != "" {
+		depth++
+	}
+	return strings.Repeat("../", depth)
+}
+
+// sitemap writes sitemap.xml and robots.txt when the site URL is known
+func (b *builder) sitemap() error {
+	if b.opts.BaseURL == "" {
+		return nil
+	}
+
+	today := time.Now().Format("2006-01-02")
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	sb.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` + "\n")
+	for _, page := range b.pages {
+		fmt.Fprintf(&sb, "  <url><loc>%s</loc><lastmod>%s</lastmod></url>\n", template.HTMLEscapeString(b.opts.BaseURL+page), today)
+	}
+	sb.WriteString("</urlset>\n")
+	if err := b.write("sitemap.xml", []byte(sb.String())); err != nil {
+		return err
+	}
+
+	robots := "User-agent: *\nAllow: /\nSitemap: " + b.opts.BaseURL + "sitemap.xml\n"
+	return b.write("robots.txt", []byte(robots))
+}
+
+// githubPages disables Jekyll so that GitHub Pages serves the files as is
+func (b *builder) githubPages() error {
+	return b.write(".nojekyll", nil)
+}
+
+// favicon writes an SVG icon with the initials on the primary color
+func (b *builder) favicon() error {
+	p := b.cv.Personal
+	initials := ""
+	for _, name := range []string{p.FirstName, p.LastName} {
+		if r := []rune(strings.TrimSpace(name)); len(r) > 0 {
+			initials += strings.ToUpper(string(r[0]))
+		}
+	}
+
+	color := b.opts.Color
+	if color == "" {
+		color = themeColor(b.gen.GetTheme())
+	}
+
+	var buf bytes.Buffer
+	err := faviconTemplate.Execute(&buf, struct{ Initials, Color string }{initials, color})
+	if err != nil {
+		return err
+	}
+	return b.write("favicon.svg", buf.Bytes())
+}
+
+var faviconTemplate = template.Must(template.New("favicon").Parse(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 64 64">
+<rect width="64" height="64" rx="14" fill="{{.Color}}"/>
+<text x="32" y="42" font-family="Segoe UI, Helvetica, Arial, sans-serif" font-size="28" font-weight="700" fill="#ffffff" text-anchor="middle">{{.Initials}}</text>
+</svg>
+`))
+
+// themeColor returns the primary color of a theme
+func themeColor(theme string) string {
+	if color := templates.GetThemePrimaryColor(theme); color != "" {
+		return color
+	}
+	return docx.DefaultColor
+}
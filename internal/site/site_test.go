@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const testCV = `personal:
+  firstName: Jane
+  lastName: Doe
+  title: Platform Engineer
+  email: jane@example.com
+  phone: "+33 6 12 34 56 78"
+  private: [phone]
+summary: Builds reliable platforms.
+experience:
+  - company: Acme
+    position: SRE
+    startDate: "2020-01"
+    endDate: present
+  - company: Secret Corp
+    position: Consultant
+    startDate: "2018-01"
+    endDate: "2019-12"
+    private: true
+projects:
+  - name: "C++ Tool!"
+    description: A command-line tool.
+    url: github.com/jane/tool
+  - name: Hidden project
+    url: github.com/jane/hidden
+    private: true
+`
+
+// build writes the test CV and builds its site, returning the output
+// directory and the files written
+func build(t *testing.T, opts Options) (string, []string) {
+	t.Helper()
+
+	src, dir := t.TempDir(), filepath.Join(t.TempDir(), "site")
+	dataPath := filepath.Join(src, "cv.yaml")
+	if err := os.WriteFile(dataPath, []byte(testCV), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := Build(dataPath, dir, opts)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return dir, files
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestBuild(t *testing.T) {
+	dir, files := build(t, Options{BaseURL: "https://jane.example.com/cv", DOCX: true, StructuredData: true})
+
+	sort.Strings(files)
+	want := []string{".nojekyll", "cv.docx", "favicon.svg", "index.html", "projects/c-tool/index.html", "projects/index.html", "robots.txt", "sitemap.xml"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("files = %v, want %v", files, want)
+	}
+	for _, name := range files {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			t.Errorf("%s listed but not written: %v", name, err)
+		}
+	}
+
+	pages := map[string][]string{
+		"index.html": {
+			`<link rel="canonical" href="https://jane.example.com/cv/">`,
+			`<meta property="og:url" content="https://jane.example.com/cv/">`,
+			`<meta property="og:type" content="profile">`,
+			`<meta property="og:title" content="Jane Doe - Platform Engineer">`,
+			`<meta property="profile:first_name" content="Jane">`,
+			`<link rel="icon" href="favicon.svg" type="image/svg+xml">`,
+			`<a href="projects/">Projects</a> <a href="cv.docx" download>DOCX</a>`,
+		},
+		"projects/c-tool/index.html": {
+			`<link rel="canonical" href="https://jane.example.com/cv/projects/c-tool/">`,
+			`<meta property="og:type" content="website">`,
+			`<meta property="og:description" content="A command-line tool.">`,
+			`<link rel="icon" href="../../favicon.svg" type="image/svg+xml">`,
+		},
+		"projects/index.html": {
+			`<link rel="canonical" href="https://jane.example.com/cv/projects/">`,
+		},
+	}
+	for page, tags := range pages {
+		html := readFile(t, dir, page)
+		for _, tag := range tags {
+			if !strings.Contains(html, tag) {
+				t.Errorf("%s: missing %s", page, tag)
+			}
+		}
+		// Private fields and entries are always left out
+		for _, private := range []string{"Secret Corp", "Hidden project", "+33 6 12 34 56 78"} {
+			if strings.Contains(html, private) {
+				t.Errorf("%s: private %q published", page, private)
+			}
+		}
+	}
+	if html := readFile(t, dir, "index.html"); strings.Contains(html, "cv.pdf") {
+		t.Error("index.html links the PDF while it is disabled")
+	}
+
+	var locs []string
+	for _, m := range regexp.MustCompile(`<loc>([^<]*)</loc>`).FindAllStringSubmatch(readFile(t, dir, "sitemap.xml"), -1) {
+		locs = append(locs, m[1])
+	}
+	wantLocs := []string{"https://jane.example.com/cv/", "https://jane.example.com/cv/projects/", "https://jane.example.com/cv/projects/c-tool/"}
+	if !reflect.DeepEqual(locs, wantLocs) {
+		t.Errorf("sitemap = %v, want %v", locs, wantLocs)
+	}
+	if robots := readFile(t, dir, "robots.txt"); !strings.Contains(robots, "Sitemap: https://jane.example.com/cv/sitemap.xml\n") {
+		t.Errorf("robots.txt = %q", robots)
+	}
+	if favicon := readFile(t, dir, "favicon.svg"); !strings.Contains(favicon, ">JD</text>") {
+		t.Errorf("favicon.svg does not show the initials:\n%s", favicon)
+	}
+}
+
+func TestBuildWithoutBaseURL(t *testing.T) {
+	dir, files := build(t, Options{})
+
+	for _, name := range files {
+		if name == "sitemap.xml" || name == "robots.txt" || name == "cv.docx" {
+			t.Errorf("%s written without a base URL or DOCX", name)
+		}
+	}
+	html := readFile(t, dir, "index.html")
+	if strings.Contains(html, `rel="canonical"`) || strings.Contains(html, "og:url") {
+		t.Error("index.html has absolute links without a base URL")
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	for name, want := range map[string]string{
+		"resumectl":        "resumectl",
+		"C++ Tool!":        "c-tool",
+		"  Hello, World  ": "hello-world",
+		"Café 2":           "caf-2",
+		"!!!":              "",
+	} {
+		if got := slugify(name); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package skills
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"resumectl/internal/forge"
+	"resumectl/internal/models"
+)
+
+// Skill categories proposed by Derive, in display order
+const (
+	CategoryLanguages      = "Languages"
+	CategoryInfrastructure = "Infrastructure"
+	CategoryFrameworks     = "Frameworks"
+)
+
+var categoryOrder = []string{CategoryLanguages, CategoryInfrastructure, CategoryFrameworks}
+
+// MaxPerCategory is the maximum number of skills proposed per category
+var MaxPerCategory = 8
+
+// halfLife is the age at which a repository counts half as much as a fresh one
+const halfLife = 365 * 24 * time.Hour
+
+// minScore drops skills weighing less than this share of the top skill
+const minScore = 0.05
+
+// topicWeight is the weight of a topic relative to the whole repository code
+const topicWeight = 0.5
+
+// aliases maps lowercase spellings (topics, language names) to canonical names
+var aliases = map[string]string{
+	"golang":              "Go",
+	"go":                  "Go",
+	"js":                  "JavaScript",
+	"javascript":          "JavaScript",
+	"ts":                  "TypeScript",
+	"typescript":          "TypeScript",
+	"py":                  "Python",
+	"python":              "Python",
+	"python3":             "Python",
+	"rust":                "Rust",
+	"rustlang":            "Rust",
+	"java":                "Java",
+	"kotlin":              "Kotlin",
+	"ruby":                "Ruby",
+	"php":                 "PHP",
+	"cpp":                 "C++",
+	"c++":                 "C++",
+	"csharp":              "C#",
+	"c#":                  "C#",
+	"shell":               "Shell",
+	"bash":                "Shell",
+	"k8s":                 "Kubernetes",
+	"kubernetes":          "Kubernetes",
+	"terraform":           "Terraform",
+	"hcl":                 "Terraform",
+	"docker":              "Docker",
+	"dockerfile":          "Docker",
+	"helm":                "Helm",
+	"ansible":             "Ansible",
+	"aws":                 "AWS",
+	"amazon-web-services": "AWS",
+	"gcp":                 "GCP",
+	"google-cloud":        "GCP",
+	"azure":               "Azure",
+	"prometheus":          "Prometheus",
+	"grafana":             "Grafana",
+	"linux":               "Linux",
+	"nix":                 "Nix",
+	"nixos":               "Nix",
+	"pulumi":              "Pulumi",
+	"argocd":              "Argo CD",
+	"argo-cd":             "Argo CD",
+	"github-actions":      "GitHub Actions",
+	"gitlab-ci":           "GitLab CI",
+	"react":               "React",
+	"reactjs":             "React",
+	"vue":                 "Vue.js",
+	"vuejs":               "Vue.js",
+	"angular":             "Angular",
+	"svelte":              "Svelte",
+	"nextjs":              "Next.js",
+	"nodejs":              "Node.js",
+	"node":                "Node.js",
+	"express":             "Express",
+	"django":              "Django",
+	"flask":               "Flask",
+	"fastapi":             "FastAPI",
+	"rails":               "Ruby on Rails",
+	"ruby-on-rails":       "Ruby on Rails",
+	"spring":              "Spring",
+	"spring-boot":         "Spring",
+	"laravel":             "Laravel",
+	"dotnet":              ".NET",
+	"flutter":             "Flutter",
+	"tensorflow":          "TensorFlow",
+	"pytorch":             "PyTorch",
+	"graphql":             "GraphQL",
+	"tailwindcss":         "Tailwind CSS",
+	"cobra":               "Cobra",
+	"bubbletea":           "Bubble Tea",
+}
+
+// categories classifies the canonical names that are not programming languages
+var categories = map[string]string{
+	"Terraform":      CategoryInfrastructure,
+	"Kubernetes":     CategoryInfrastructure,
+	"Docker":         CategoryInfrastructure,
+	"Helm":           CategoryInfrastructure,
+	"Ansible":        CategoryInfrastructure,
+	"AWS":            CategoryInfrastructure,
+	"GCP":            CategoryInfrastructure,
+	"Azure":          CategoryInfrastructure,
+	"Prometheus":     CategoryInfrastructure,
+	"Grafana":        CategoryInfrastructure,
+	"Linux":          CategoryInfrastructure,
+	"Nix":            CategoryInfrastructure,
+	"Pulumi":         CategoryInfrastructure,
+	"Argo CD":        CategoryInfrastructure,
+	"GitHub Actions": CategoryInfrastructure,
+	"GitLab CI":      CategoryInfrastructure,
+	"React":          CategoryFrameworks,
+	"Vue.js":         CategoryFrameworks,
+	"Angular":        CategoryFrameworks,
+	"Svelte":         CategoryFrameworks,
+	"Next.js":        CategoryFrameworks,
+	"Node.js":        CategoryFrameworks,
+	"Express":        CategoryFrameworks,
+	"Django":         CategoryFrameworks,
+	"Flask":          CategoryFrameworks,
+	"FastAPI":        CategoryFrameworks,
+	"Ruby on Rails":  CategoryFrameworks,
+	"Spring":         CategoryFrameworks,
+	"Laravel":        CategoryFrameworks,
+	".NET":           CategoryFrameworks,
+	"Flutter":        CategoryFrameworks,
+	"TensorFlow":     CategoryFrameworks,
+	"PyTorch":        CategoryFrameworks,
+	"GraphQL":        CategoryFrameworks,
+	"Tailwind CSS":   CategoryFrameworks,
+	"Cobra":          CategoryFrameworks,
+	"Bubble Tea":     CategoryFrameworks,
+}
+
+// ignored lists languages reported by forges that are not worth a skill
+var ignored = map[string]bool{
+	"Makefile":  true,
+	"Batchfile": true,
+	"Procfile":  true,
+	"Roff":      true,
+	"CMake":     true,
+	"M4":        true,
+	"Jinja":     true,
+	"Smarty":    true,
+	"Mustache":  true,
+}
+
+// Normalize returns the canonical spelling of a skill ("golang" → "Go",
+// "k8s" → "Kubernetes"). Unknown names are returned trimmed.
+func Normalize(name string) string {
+	name = strings.TrimSpace(name)
+	if canonical, ok := aliases[strings.ToLower(name)]; ok {
+		return canonical
+	}
+	return name
+}
+
+// Lookup returns the canonical name of a known skill spelling, compared
+// case-insensitively ("K8S" → "Kubernetes")
+func Lookup(name string) (string, bool) {
+	canonical, ok := aliases[strings.ToLower(strings.TrimSpace(name))]
+	return canonical, ok
+}
+
+// Derive proposes skill categories from imported repositories. Each
+// repository weighs the same whatever its forge: its languages share that
+// weight by their part of its code, and its topics weigh half of it; both
+// decay with the time since the last push. Topics are only kept when they
+// are known skills.
+func Derive(projects []forge.Project) []models.SkillCategory {
+	return derive(projects, time.Now())
+}
+
+func derive(projects []forge.Project, now time.Time) []models.SkillCategory {
+	scores := make(map[string]float64)
+
+	for _, proj := range projects {
+		languages := proj.Languages
+		if len(languages) == 0 && len(proj.Technologies) > 0 {
+			// Sources without a language breakdown list the main language
+			// first in the technologies, followed by the topics scored below
+			if main := proj.Technologies[0]; !forge.ContainsIgnoreCase(proj.Topics, main) {
+				languages = map[string]int{main: 1}
+			}
+		}
+
+		// Forges report bytes of code (GitHub, Gitea), percentages (GitLab)
+		// or nothing but the main language: only shares are comparable
+		total := 0
+		for _, size := range languages {
+			total += size
+		}
+		if total == 0 {
+			continue
+		}
+		weight := recency(proj.PushedAt, now)
+
+		for language, size := range languages {
+			name := Normalize(language)
+			if ignored[name] {
+				continue
+			}
+			scores[name] += weight * float64(size) / float64(total)
+		}
+
+		for _, topic := range proj.Topics {
+			if _, known := aliases[strings.ToLower(topic)]; known {
+				scores[Normalize(topic)] += weight * topicWeight
+			}
+		}
+	}
+
+	var top float64
+	for _, score := range scores {
+		top = math.Max(top, score)
+	}
+
+	grouped := make(map[string][]string)
+	for name, score := range scores {
+		if score < top*minScore {
+			continue
+		}
+		category := categories[name]
+		if category == "" {
+			category = CategoryLanguages
+		}
+		grouped[category] = append(grouped[category], name)
+	}
+
+	var result []models.SkillCategory
+	for _, category := range categoryOrder {
+		names := grouped[category]
+		if len(names) == 0 {
+			continue
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if scores[names[i]] != scores[names[j]] {
+				return scores[names[i]] > scores[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		if len(names) > MaxPerCategory {
+			names = names[:MaxPerCategory]
+		}
+		result = append(result, models.SkillCategory{Category: category, Items: names})
+	}
+
+	return result
+}
+
+// recency weighs a repository by the time since its last push: 1 for a
+// fresh one, halving every year. Unknown dates count as one year old.
+func recency(pushedAt, now time.Time) float64 {
+	if pushedAt.IsZero() {
+		return 0.5
+	}
+	age := now.Sub(pushedAt)
+	if age < 0 {
+		age = 0
+	}
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
+
+// Missing returns the derived skills that are not already listed in any
+// category of existing (compared after normalization)
+func Missing(existing, derived []models.SkillCategory) []models.SkillCategory {
+	known := make(map[string]bool)
+	for _, category := range existing {
+		for _, item := range category.Items {
+			known[strings.ToLower(Normalize(item))] = true
+		}
+	}
+
+	var result []models.SkillCategory
+	for _, category := range derived {
+		var items []string
+		for _, item := range category.Items {
+			if !known[strings.ToLower(Normalize(item))] {
+				items = append(items, item)
+			}
+		}
+		if len(items) > 0 {
+			result = append(result, models.SkillCategory{Category: category.Category, Items: items})
+		}
+	}
+	return result
+}
+
+// Merge adds the derived skills missing from existing: to the category of
+// the same name if there is one, otherwise as a new category
+func Merge(existing, derived []models.SkillCategory) []models.SkillCategory {
+	for _, category := range Missing(existing, derived) {
+		found := false
+		for i := range existing {
+			if strings.EqualFold(existing[i].Category, category.Category) {
+				existing[i].Items = append(existing[i].Items, category.Items...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, category)
+		}
+	}
+	return existing
+}
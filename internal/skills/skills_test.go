@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package skills
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"resumectl/internal/forge"
+	"resumectl/internal/models"
+)
+
+func TestDeriveForgeIndependent(t *testing.T) {
+	now := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	pushed := now.AddDate(0, -1, 0)
+
+	projects := []forge.Project{
+		// GitHub: bytes of code
+		{Languages: map[string]int{"Rust": 480000, "Shell": 20000}, PushedAt: pushed},
+		// GitLab: percentages times 100
+		{Languages: map[string]int{"Python": 9600, "Shell": 400}, PushedAt: pushed},
+		// Bitbucket: main language only
+		{Technologies: []string{"Go"}, PushedAt: pushed},
+	}
+
+	// Each main language weighs the same, so they are sorted by name
+	want := []models.SkillCategory{{Category: CategoryLanguages, Items: []string{"Go", "Python", "Rust", "Shell"}}}
+	if got := derive(projects, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("derive = %+v, want %+v", got, want)
+	}
+}
+
+func TestDeriveRecencyAndTopics(t *testing.T) {
+	now := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	projects := []forge.Project{
+		{Languages: map[string]int{"Java": 1000}, PushedAt: now.AddDate(-3, 0, 0)},
+		{Languages: map[string]int{"Go": 1000}, Topics: []string{"kubernetes", "hacktoberfest"}, PushedAt: now},
+	}
+
+	want := []models.SkillCategory{
+		{Category: CategoryLanguages, Items: []string{"Go", "Java"}},
+		{Category: CategoryInfrastructure, Items: []string{"Kubernetes"}},
+	}
+	if got := derive(projects, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("derive = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package spell
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxCompoundParts bounds the number of words of a compound
+const maxCompoundParts = 4
+
+// form is a decomposition of a word into a dictionary root and affixes
+type form struct {
+	flags  []flag
+	prefix *affix
+	suffix *affix // Outer suffix
+	inner  *affix // Suffix between the root and the outer suffix
+}
+
+// forms calls yield with the decompositions of word until it returns true
+func (d *Dictionary) forms(word string, yield func(form) bool) bool {
+	for _, flags := range d.words[word] {
+		if yield(form{flags: flags}) {
+			return true
+		}
+	}
+	if d.suffixForms(word, nil, yield) {
+		return true
+	}
+
+	for _, i := range prefixEnds(word) {
+		for _, pfx := range d.prefixes[word[:i]] {
+			root := pfx.strip + word[i:]
+			if root == "" || !matchPrefixCondition(root, pfx.cond) {
+				continue
+			}
+			for _, flags := range d.words[root] {
+				if yield(form{flags: flags, prefix: pfx}) {
+					return true
+				}
+			}
+			if pfx.cross && d.suffixForms(root, pfx, yield) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// suffixForms yields the suffixed decompositions of word, under prefix
+func (d *Dictionary) suffixForms(word string, prefix *affix, yield func(form) bool) bool {
+	for _, i := range suffixStarts(word) {
+		for _, sfx := range d.suffixes[word[i:]] {
+			if prefix != nil && !sfx.cross {
+				continue
+			}
+			stem := word[:i] + sfx.strip
+			if stem == "" || !matchSuffixCondition(stem, sfx.cond) {
+				continue
+			}
+			for _, flags := range d.words[stem] {
+				if yield(form{flags: flags, prefix: prefix, suffix: sfx}) {
+					return true
+				}
+			}
+			if prefix != nil {
+				continue
+			}
+
+			// Twofold suffixes: the inner one allows the outer one
+			for _, j := range suffixStarts(stem) {
+				for _, in := range d.suffixes[stem[j:]] {
+					if !hasFlag(in.cont, sfx.flag) {
+						continue
+					}
+					root := stem[:j] + in.strip
+					if root == "" || !matchSuffixCondition(root, in.cond) {
+						continue
+					}
+					for _, flags := range d.words[root] {
+						if yield(form{flags: flags, suffix: sfx, inner: in}) {
+							return true
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// prefixEnds returns the byte offsets ending the non-empty prefixes of word
+func prefixEnds(word string) []int {
+	var ends []int
+	for i := range word {
+		if i > 0 {
+			ends = append(ends, i)
+		}
+	}
+	return ends
+}
+
+// suffixStarts returns the byte offsets starting the suffixes of word,
+// including the empty one
+func suffixStarts(word string) []int {
+	return append(prefixEnds(word), len(word))
+}
+
+// affixesAllowed reports whether the root flags and the continuation
+// flags of the affixes allow the affixes of f
+func (f form) affixesAllowed() bool {
+	allowed := func(a *affix) bool {
+		if hasFlag(f.flags, a.flag) {
+			return true
+		}
+		for _, other := range []*affix{f.prefix, f.suffix, f.inner} {
+			if other != nil && other != a && hasFlag(other.cont, a.flag) {
+				return true
+			}
+		}
+		return false
+	}
+	if f.prefix != nil && !allowed(f.prefix) {
+		return false
+	}
+	if f.inner != nil {
+		return hasFlag(f.flags, f.inner.flag) && hasFlag(f.inner.cont, f.suffix.flag)
+	}
+	return f.suffix == nil || allowed(f.suffix)
+}
+
+// valid reports whether f is a standalone word
+func (d *Dictionary) valid(f form) bool {
+	if hasFlag(f.flags, d.forbidden) || hasFlag(f.flags, d.onlyInCompound) {
+		return false
+	}
+	affixed := f.prefix != nil || f.suffix != nil
+	if !affixed {
+		return !hasFlag(f.flags, d.needAffix)
+	}
+	if d.circumfix != 0 {
+		// A circumfix is a prefix and a suffix that only go together
+		prefix := f.prefix != nil && hasFlag(f.prefix.cont, d.circumfix)
+		suffix := f.suffix != nil && hasFlag(f.suffix.cont, d.circumfix) ||
+			f.inner != nil && hasFlag(f.inner.cont, d.circumfix)
+		if prefix != suffix {
+			return false
+		}
+	}
+	outer := f.suffix
+	if outer == nil {
+		outer = f.prefix
+	}
+	if hasFlag(outer.cont, d.needAffix) && (f.prefix == nil || f.suffix == nil) {
+		return false
+	}
+	return f.affixesAllowed()
+}
+
+// Check reports whether word is spelled correctly. Capitalized and
+// upper-case words also match their lower-case dictionary entries.
+func (d *Dictionary) Check(word string) bool {
+	word = d.convert(word)
+	for _, flags := range d.words[word] {
+		if hasFlag(flags, d.forbidden) {
+			return false
+		}
+	}
+	for _, variant := range caseVariants(word) {
+		if d.forms(variant, d.valid) || d.checkCompound(variant) {
+			return true
+		}
+	}
+	return false
+}
+
+// convert applies the ICONV table of the dictionary to word
+func (d *Dictionary) convert(word string) string {
+	for _, conv := range d.iconv {
+		word = strings.ReplaceAll(word, conv[0], conv[1])
+	}
+	return word
+}
+
+// caseVariants returns word and the spellings it may have in a dictionary
+func caseVariants(word string) []string {
+	variants := []string{word}
+	lower := strings.ToLower(word)
+	if lower == word {
+		return variants
+	}
+	title := capitalize(lower)
+	switch {
+	case word == title:
+		variants = append(variants, lower)
+	case word == strings.ToUpper(word):
+		variants = append(variants, title, lower)
+	}
+	return variants
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// checkCompound reports whether word joins dictionary words allowed in
+// compounds, like the German "Softwareentwicklung"
+func (d *Dictionary) checkCompound(word string) bool {
+	if d.compound == 0 && d.compoundBegin == 0 {
+		return false
+	}
+	return d.compoundFrom(word, 0)
+}
+
+func (d *Dictionary) compoundFrom(word string, part int) bool {
+	if part >= maxCompoundParts {
+		return false
+	}
+	count := 0
+	for i := range word {
+		if count >= d.compoundMin && utf8.RuneCountInString(word[i:]) >= d.compoundMin {
+			if d.compoundPart(word[:i], part, false) &&
+				(d.compoundPart(word[i:], part+1, true) || d.compoundFrom(word[i:], part+1)) {
+				return true
+			}
+		}
+		count++
+	}
+	return false
+}
+
+// compoundPart reports whether s can be the part-th word of a compound.
+// Nouns are capitalized in the dictionary but not inside compounds.
+func (d *Dictionary) compoundPart(s string, part int, last bool) bool {
+	position := d.compoundMiddle
+	switch {
+	case part == 0:
+		position = d.compoundBegin
+	case last:
+		position = d.compoundEnd
+	}
+
+	accept := func(f form) bool {
+		if hasFlag(f.flags, d.forbidden) || !f.affixesAllowed() {
+			return false
+		}
+		// The root or an affix ("s" in "Entwicklungsprozess") allows the position
+		for _, flags := range [][]flag{f.flags, cont(f.prefix), cont(f.suffix)} {
+			if hasFlag(flags, d.compound) || hasFlag(flags, position) {
+				return true
+			}
+		}
+		return false
+	}
+	variants := []string{s}
+	if part > 0 {
+		if r, _ := utf8.DecodeRuneInString(s); unicode.IsUpper(r) {
+			return false
+		}
+		variants = append(variants, capitalize(s))
+	}
+	for _, variant := range variants {
+		if d.forms(variant, accept) {
+			return true
+		}
+	}
+	return false
+}
+
+func cont(a *affix) []flag {
+	if a == nil {
+		return nil
+	}
+	return a.cont
+}
+
+// maxSuggestions is the number of suggestions returned for a word
+const maxSuggestions = 3
+
+// Suggest returns spellings close to a misspelled word: replacements from
+// the REP table, then single edits with the TRY characters, then splits
+// in two words
+func (d *Dictionary) Suggest(word string) []string {
+	word = d.convert(word)
+	var suggestions []string
+	seen := map[string]bool{word: true}
+	add := func(candidate string) bool {
+		if seen[candidate] {
+			return false
+		}
+		seen[candidate] = true
+		ok := true
+		for _, w := range strings.Fields(candidate) {
+			ok = ok && d.Check(w)
+		}
+		if ok && candidate != "" {
+			suggestions = append(suggestions, candidate)
+		}
+		return len(suggestions) >= maxSuggestions
+	}
+
+	for _, rep := range d.rep {
+		for i := 0; ; {
+			j := strings.Index(word[i:], rep[0])
+			if j < 0 {
+				break
+			}
+			i += j
+			if add(word[:i] + rep[1] + word[i+len(rep[0]):]) {
+				return suggestions
+			}
+			i++
+		}
+	}
+
+	try := d.try
+	if try == "" {
+		try = "esianrtolcdugmphbyfvkwzESIANRTOLCDUGMPHBYFVKWZ"
+	}
+	// Swapped neighbours first, the most common typo, then replaced,
+	// inserted and deleted characters, then splits in two words
+	runes := []rune(word)
+	var candidates []string
+	for i := 0; i+1 < len(runes); i++ {
+		swapped := append([]rune{}, runes...)
+		swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+		candidates = append(candidates, string(swapped))
+	}
+	for i := range runes {
+		for _, r := range try {
+			if r != runes[i] {
+				candidates = append(candidates, string(runes[:i])+string(r)+string(runes[i+1:]))
+			}
+		}
+	}
+	for i := 0; i <= len(runes); i++ {
+		for _, r := range try {
+			candidates = append(candidates, string(runes[:i])+string(r)+string(runes[i:]))
+		}
+	}
+	for i := range runes {
+		candidates = append(candidates, string(runes[:i])+string(runes[i+1:]))
+	}
+	for i := 1; i < len(runes); i++ {
+		candidates = append(candidates, string(runes[:i])+" "+string(runes[i:]))
+	}
+
+	for _, candidate := range candidates {
+		if add(candidate) {
+			break
+		}
+	}
+	return suggestions
+}
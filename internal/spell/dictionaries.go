@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package spell
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// bundled holds the dictionaries built into the binary
+//
+//go:embed dictionaries
+var bundled embed.FS
+
+// names lists the dictionary file names tried for a language, by
+// preference. Other languages are looked up by their own name (en_AU).
+var names = map[string][]string{
+	"en": {"en_US", "en_GB", "en"},
+	"fr": {"fr_FR", "fr"},
+	"de": {"de_DE", "de_DE_frami", "de"},
+}
+
+// Dirs returns the directories searched for dictionaries after the bundled
+// ones: $DICPATH, the resumectl configuration directory and the usual
+// Hunspell locations
+func Dirs() []string {
+	var dirs []string
+	if path := os.Getenv("DICPATH"); path != "" {
+		dirs = append(dirs, filepath.SplitList(path)...)
+	}
+	if config, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(config, "resumectl", "dictionaries"))
+	}
+	dirs = append(dirs, "/usr/share/hunspell", "/usr/share/myspell", "/usr/share/myspell/dicts",
+		"/usr/local/share/hunspell", "/Library/Spelling")
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, "Library", "Spelling"))
+	}
+	return dirs
+}
+
+// Load returns the dictionary of a language (en, fr, de, or a Hunspell
+// name such as en_GB), from the bundled dictionaries or from Dirs
+func Load(lang string) (*Dictionary, error) {
+	candidates, ok := names[strings.ToLower(lang)]
+	if !ok {
+		candidates = []string{lang}
+	}
+
+	for _, name := range candidates {
+		aff, errAff := fs.ReadFile(bundled, "dictionaries/"+name+".aff")
+		dic, errDic := fs.ReadFile(bundled, "dictionaries/"+name+".dic")
+		if errAff == nil && errDic == nil {
+			return parseNamed(name, aff, dic)
+		}
+	}
+	for _, dir := range Dirs() {
+		for _, name := range candidates {
+			aff, errAff := os.ReadFile(filepath.Join(dir, name+".aff"))
+			dic, errDic := os.ReadFile(filepath.Join(dir, name+".dic"))
+			if errAff == nil && errDic == nil {
+				return parseNamed(name, aff, dic)
+			}
+		}
+	}
+	return nil, fmt.Errorf("no %s dictionary found: add %s.aff and %s.dic to $DICPATH or %s",
+		lang, candidates[0], candidates[0], Dirs()[0])
+}
+
+func parseNamed(name string, aff, dic []byte) (*Dictionary, error) {
+	d, err := Parse(aff, dic)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary %s: %w", name, err)
+	}
+	return d, nil
+}
+
+// Checker checks words against several dictionaries and a personal word
+// list. A word is correct if any of them accepts it.
+type Checker struct {
+	dictionaries []*Dictionary
+	words        map[string]bool // Lower-case
+}
+
+// NewChecker loads the dictionaries of langs
+func NewChecker(langs []string) (*Checker, error) {
+	c := &Checker{words: make(map[string]bool)}
+	for _, lang := range langs {
+		d, err := Load(lang)
+		if err != nil {
+			return nil, err
+		}
+		c.dictionaries = append(c.dictionaries, d)
+	}
+	return c, nil
+}
+
+// AddWords accepts words regardless of the dictionaries, in any case
+func (c *Checker) AddWords(words ...string) {
+	for _, word := range words {
+		c.words[strings.ToLower(word)] = true
+	}
+}
+
+// LoadWordList adds the words of a personal word list: one word per line,
+// lines starting with # are comments
+func (c *Checker) LoadWordList(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			c.AddWords(line)
+		}
+	}
+	return scanner.Err()
+}
+
+// Check reports whether word is in the personal word list or in one of
+// the dictionaries
+func (c *Checker) Check(word string) bool {
+	if c.words[strings.ToLower(word)] {
+		return true
+	}
+	for _, d := range c.dictionaries {
+		if d.Check(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Suggest returns corrections from the first dictionary that has some
+func (c *Checker) Suggest(word string) []string {
+	for _, d := range c.dictionaries {
+		if suggestions := d.Suggest(word); len(suggestions) > 0 {
+			return suggestions
+		}
+	}
+	return nil
+}
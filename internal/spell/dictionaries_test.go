@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package spell
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadBundled(t *testing.T) {
+	t.Setenv("DICPATH", "")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	tests := map[string]struct {
+		good, bad []string
+	}{
+		"en": {
+			[]string{"the", "Improved", "deployments", "didn't", "managing"},
+			[]string{"teh", "managment", "developement"},
+		},
+		"fr": {
+			[]string{"équipe", "l'équipe", "l’équipe", "développement", "aujourd'hui"},
+			[]string{"equipe", "développment", "sytème"},
+		},
+		"de": {
+			[]string{"Entwicklung", "Softwareentwicklung", "gearbeitet", "Straße"},
+			[]string{"Entwiklung", "gearbeit"},
+		},
+	}
+	for lang, tt := range tests {
+		t.Run(lang, func(t *testing.T) {
+			d, err := Load(lang)
+			if err != nil {
+				t.Fatalf("Load(%q): %v", lang, err)
+			}
+			expect(t, d, tt.good, tt.bad)
+		})
+	}
+}
+
+func TestLoadDICPATH(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "xx_XX.aff"), []byte("SET UTF-8\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "xx_XX.dic"), []byte("1\nresumectl\n"), 0644)
+	t.Setenv("DICPATH", dir)
+
+	d, err := Load("xx_XX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, d, []string{"resumectl"}, []string{"resume"})
+
+	if _, err := Load("zz"); err == nil {
+		t.Error("Load of a missing language succeeded")
+	}
+}
+
+func TestChecker(t *testing.T) {
+	t.Setenv("DICPATH", "")
+	c, err := NewChecker([]string{"en", "fr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	words := filepath.Join(t.TempDir(), "words.txt")
+	os.WriteFile(words, []byte("# Names\nBriault\nkubectl\n"), 0644)
+	if err := c.LoadWordList(words); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, w := range []string{"team", "équipe", "Briault", "briault", "Kubectl"} {
+		if !c.Check(w) {
+			t.Errorf("Check(%q) = false, want true", w)
+		}
+	}
+	if c.Check("teh") {
+		t.Error(`Check("teh") = true, want false`)
+	}
+}
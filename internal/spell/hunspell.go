@@ -0,0 +1,386 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package spell checks words against Hunspell dictionaries (.aff/.dic).
+// It supports the affix rules (prefixes, suffixes, cross products and
+// twofold suffixes), flag aliases, forbidden words, simple compounding and
+// the TRY/REP suggestion tables, input conversions (ICONV) and circumfixes, which is what the common en, fr and de
+// dictionaries rely on. Morphology and compound rules are ignored.
+package spell
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// flag identifies an affix class or a word property
+type flag uint32
+
+type flagMode int
+
+const (
+	flagChar flagMode = iota // One character per flag (default)
+	flagLong                 // Two characters per flag
+	flagNum                  // Comma-separated numbers
+)
+
+// charClass is a position of an affix condition: one character, a set of
+// characters, a negated set, or any character
+type charClass struct {
+	chars  string
+	negate bool
+	any    bool
+}
+
+func (c charClass) match(r rune) bool {
+	if c.any {
+		return true
+	}
+	return strings.ContainsRune(c.chars, r) != c.negate
+}
+
+// affix is a prefix or suffix rule: the stripped characters are replaced
+// by add when the root matches the condition
+type affix struct {
+	flag  flag
+	strip string
+	add   string
+	cont  []flag // Continuation flags (affixes allowed on top of this one)
+	cond  []charClass
+	cross bool // Can combine with an affix of the other kind
+}
+
+// Dictionary is a parsed Hunspell dictionary
+type Dictionary struct {
+	words    map[string][][]flag // Homonyms share a spelling with their own flags
+	prefixes map[string][]*affix // By added string
+	suffixes map[string][]*affix
+
+	mode    flagMode
+	aliases [][]flag
+
+	needAffix, forbidden, onlyInCompound flag
+	circumfix                            flag
+	compound, compoundBegin              flag
+	compoundMiddle, compoundEnd          flag
+	compoundMin                          int
+
+	try   string
+	rep   [][2]string
+	iconv [][2]string // Input conversions, such as ’ to '
+}
+
+// Parse reads a dictionary from the contents of its .aff and .dic files
+func Parse(aff, dic []byte) (*Dictionary, error) {
+	d := &Dictionary{
+		words:       make(map[string][][]flag),
+		prefixes:    make(map[string][]*affix),
+		suffixes:    make(map[string][]*affix),
+		compoundMin: 3,
+	}
+
+	decode, err := decoder(aff)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.parseAff(decode(aff)); err != nil {
+		return nil, fmt.Errorf("affix file: %w", err)
+	}
+	d.parseDic(decode(dic))
+	return d, nil
+}
+
+// decoder returns a function converting the dictionary files to UTF-8,
+// from the encoding given by the SET line of the affix file
+func decoder(aff []byte) (func([]byte) string, error) {
+	set := "ISO8859-1"
+	scanner := bufio.NewScanner(bytes.NewReader(aff))
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) >= 2 && fields[0] == "SET" {
+			set = strings.ToUpper(fields[1])
+			break
+		}
+	}
+
+	switch set {
+	case "UTF-8":
+		return func(b []byte) string { return strings.TrimPrefix(string(b), "\ufeff") }, nil
+	case "ISO8859-1", "ISO-8859-1", "ISO8859-15", "ISO-8859-15":
+		// Latin-9 only differs from Latin-1 by a few rare symbols
+		return func(b []byte) string {
+			runes := make([]rune, len(b))
+			for i, c := range b {
+				runes[i] = rune(c)
+			}
+			return string(runes)
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported dictionary encoding %s (use UTF-8 or ISO8859-1)", set)
+}
+
+func (d *Dictionary) parseAff(content string) error {
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		fields := strings.Fields(lines[i])
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		switch fields[0] {
+		case "FLAG":
+			switch fields[1] {
+			case "long":
+				d.mode = flagLong
+			case "num":
+				d.mode = flagNum
+			}
+		case "AF":
+			n, _ := strconv.Atoi(fields[1])
+			for ; n > 0 && i+1 < len(lines); n-- {
+				i++
+				if alias := strings.Fields(lines[i]); len(alias) >= 2 && alias[0] == "AF" {
+					d.aliases = append(d.aliases, d.parseFlags(alias[1], false))
+				}
+			}
+		case "NEEDAFFIX", "PSEUDOROOT":
+			d.needAffix = d.parseFlag(fields[1])
+		case "FORBIDDENWORD":
+			d.forbidden = d.parseFlag(fields[1])
+		case "CIRCUMFIX":
+			d.circumfix = d.parseFlag(fields[1])
+		case "ONLYINCOMPOUND":
+			d.onlyInCompound = d.parseFlag(fields[1])
+		case "COMPOUNDFLAG":
+			d.compound = d.parseFlag(fields[1])
+		case "COMPOUNDBEGIN":
+			d.compoundBegin = d.parseFlag(fields[1])
+		case "COMPOUNDMIDDLE":
+			d.compoundMiddle = d.parseFlag(fields[1])
+		case "COMPOUNDEND":
+			d.compoundEnd = d.parseFlag(fields[1])
+		case "COMPOUNDMIN":
+			if n, err := strconv.Atoi(fields[1]); err == nil && n > 0 {
+				d.compoundMin = n
+			}
+		case "TRY":
+			d.try = fields[1]
+		case "REP", "ICONV":
+			table := &d.rep
+			if fields[0] == "ICONV" {
+				table = &d.iconv
+			}
+			n, _ := strconv.Atoi(fields[1])
+			for ; n > 0 && i+1 < len(lines); n-- {
+				i++
+				if pair := strings.Fields(lines[i]); len(pair) >= 3 && pair[0] == fields[0] {
+					*table = append(*table, [2]string{
+						strings.ReplaceAll(pair[1], "_", " "),
+						strings.ReplaceAll(pair[2], "_", " "),
+					})
+				}
+			}
+		case "PFX", "SFX":
+			if len(fields) < 4 {
+				return fmt.Errorf("line %d: invalid affix header", i+1)
+			}
+			class := d.parseFlag(fields[1])
+			cross := fields[2] == "Y"
+			n, err := strconv.Atoi(fields[3])
+			if err != nil {
+				return fmt.Errorf("line %d: invalid affix count %q", i+1, fields[3])
+			}
+			for ; n > 0 && i+1 < len(lines); n-- {
+				i++
+				entry := strings.Fields(lines[i])
+				if len(entry) < 4 || entry[0] != fields[0] {
+					continue
+				}
+				a := d.parseAffix(class, cross, entry[2], entry[3], entry[4:])
+				if fields[0] == "PFX" {
+					d.prefixes[a.add] = append(d.prefixes[a.add], a)
+				} else {
+					d.suffixes[a.add] = append(d.suffixes[a.add], a)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Dictionary) parseAffix(class flag, cross bool, strip, add string, rest []string) *affix {
+	a := &affix{flag: class, cross: cross}
+	if strip != "0" {
+		a.strip = strip
+	}
+	if add, cont, ok := strings.Cut(add, "/"); ok {
+		a.cont = d.parseFlags(cont, true)
+		a.add = add
+	} else {
+		a.add = add
+	}
+	if a.add == "0" {
+		a.add = ""
+	}
+	if len(rest) > 0 && rest[0] != "." {
+		a.cond = parseCondition(rest[0])
+	}
+	return a
+}
+
+// parseCondition parses an affix condition, a simplified regular
+// expression of characters, [sets], [^negated sets] and dots
+func parseCondition(s string) []charClass {
+	var cond []charClass
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '.':
+			cond = append(cond, charClass{any: true})
+		case '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			class := charClass{chars: string(runes[i+1 : min(end, len(runes))])}
+			if strings.HasPrefix(class.chars, "^") {
+				class.chars, class.negate = class.chars[1:], true
+			}
+			cond = append(cond, class)
+			i = end
+		default:
+			cond = append(cond, charClass{chars: string(runes[i])})
+		}
+	}
+	return cond
+}
+
+func (d *Dictionary) parseDic(content string) {
+	lines := strings.Split(content, "\n")
+	// The first line is the approximate number of words
+	for _, line := range lines[1:] {
+		line = strings.TrimRight(line, "\r")
+		if line == "" || line[0] == '\t' || line[0] == '#' {
+			continue
+		}
+		// Morphological fields follow a tab or a space
+		if i := strings.IndexAny(line, "\t "); i > 0 {
+			line = line[:i]
+		}
+
+		word, flags := line, ""
+		// A slash in a word is escaped with a backslash
+		for i := 0; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+				continue
+			}
+			if line[i] == '/' {
+				word, flags = line[:i], line[i+1:]
+				break
+			}
+		}
+		word = strings.ReplaceAll(word, `\/`, "/")
+		d.words[word] = append(d.words[word], d.parseFlags(flags, true))
+	}
+}
+
+// parseFlags parses a flag list, or an AF alias number when aliases are
+// defined and resolve is set
+func (d *Dictionary) parseFlags(s string, resolve bool) []flag {
+	if s == "" {
+		return nil
+	}
+	if resolve && len(d.aliases) > 0 {
+		if n, err := strconv.Atoi(s); err == nil && n >= 1 && n <= len(d.aliases) {
+			return d.aliases[n-1]
+		}
+	}
+
+	var flags []flag
+	switch d.mode {
+	case flagNum:
+		for _, n := range strings.Split(s, ",") {
+			if v, err := strconv.Atoi(strings.TrimSpace(n)); err == nil {
+				flags = append(flags, flag(v))
+			}
+		}
+	case flagLong:
+		runes := []rune(s)
+		for i := 0; i+1 < len(runes); i += 2 {
+			flags = append(flags, flag(runes[i])<<16|flag(runes[i+1]))
+		}
+	default:
+		for _, r := range s {
+			flags = append(flags, flag(r))
+		}
+	}
+	return flags
+}
+
+func (d *Dictionary) parseFlag(s string) flag {
+	if flags := d.parseFlags(s, false); len(flags) > 0 {
+		return flags[0]
+	}
+	return 0
+}
+
+func hasFlag(flags []flag, f flag) bool {
+	if f == 0 {
+		return false
+	}
+	for _, g := range flags {
+		if g == f {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSuffixCondition reports whether the end of root matches cond
+func matchSuffixCondition(root string, cond []charClass) bool {
+	if len(cond) == 0 {
+		return true
+	}
+	if utf8.RuneCountInString(root) < len(cond) {
+		return false
+	}
+	runes := []rune(root)
+	runes = runes[len(runes)-len(cond):]
+	for i, c := range cond {
+		if !c.match(runes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchPrefixCondition reports whether the start of root matches cond
+func matchPrefixCondition(root string, cond []charClass) bool {
+	i := 0
+	for _, r := range root {
+		if i == len(cond) {
+			return true
+		}
+		if !cond[i].match(r) {
+			return false
+		}
+		i++
+	}
+	return i == len(cond)
+}
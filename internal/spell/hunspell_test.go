@@ -0,0 +1,192 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package spell
+
+import (
+	"slices"
+	"testing"
+)
+
+// parse builds a dictionary from inline .aff and .dic contents
+func parse(t *testing.T, aff, dic string) *Dictionary {
+	t.Helper()
+	d, err := Parse([]byte(aff), []byte(dic))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return d
+}
+
+// expect checks the words that must be accepted and rejected
+func expect(t *testing.T, d *Dictionary, good, bad []string) {
+	t.Helper()
+	for _, w := range good {
+		if !d.Check(w) {
+			t.Errorf("Check(%q) = false, want true", w)
+		}
+	}
+	for _, w := range bad {
+		if d.Check(w) {
+			t.Errorf("Check(%q) = true, want false", w)
+		}
+	}
+}
+
+func TestAffixes(t *testing.T) {
+	d := parse(t, `SET UTF-8
+FORBIDDENWORD !
+NEEDAFFIX ?
+
+PFX U Y 1
+PFX U 0 un .
+
+SFX S Y 3
+SFX S y ies [^aeiou]y
+SFX S 0 s [aeiou]y
+SFX S 0 s [^y]
+
+SFX D Y 2
+SFX D 0 d e
+SFX D 0 ed [^e]
+
+SFX R Y 1
+SFX R 0 er/S .
+`, `7
+team/S
+deploy/DS
+manage/D
+test/DRU
+company/S
+Paris
+teh/!
+`)
+
+	expect(t, d,
+		[]string{"team", "teams", "deploys", "deployed", "managed", "companies",
+			"tested", "untested", "tester", "testers", "Team", "TEAM", "Paris", "PARIS"},
+		[]string{"teamed", "companys", "manages", "untestd", "paris", "teh", "Teh", "xyz", ""})
+}
+
+func TestNeedAffixAndCircumfix(t *testing.T) {
+	d := parse(t, `SET UTF-8
+NEEDAFFIX h
+CIRCUMFIX f
+
+PFX G Y 1
+PFX G 0 ge/f .
+
+SFX T Y 2
+SFX T 0 et/f .
+SFX T 0 en .
+`, `1
+arbeit/hGT
+`)
+
+	expect(t, d, []string{"gearbeitet", "arbeiten"}, []string{"arbeit", "gearbeit", "arbeitet", "gearbeiten"})
+}
+
+func TestFlagModes(t *testing.T) {
+	tests := []struct {
+		name string
+		aff  string
+		dic  string
+	}{
+		{"long", "FLAG long\nSFX Aa Y 1\nSFX Aa 0 s .\nSFX Bb Y 1\nSFX Bb 0 ed .\n", "1\nwork/AaBb\n"},
+		{"num", "FLAG num\nSFX 101 Y 1\nSFX 101 0 s .\nSFX 7 Y 1\nSFX 7 0 ed .\n", "1\nwork/101,7\n"},
+		{"aliases", "AF 1\nAF AB\nSFX A Y 1\nSFX A 0 s .\nSFX B Y 1\nSFX B 0 ed .\n", "1\nwork/1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := parse(t, "SET UTF-8\n"+tt.aff, tt.dic)
+			expect(t, d, []string{"work", "works", "worked"}, []string{"working", "worke"})
+		})
+	}
+}
+
+func TestLatin1(t *testing.T) {
+	// "équipe" and its plural in ISO8859-1
+	aff := []byte("SET ISO8859-1\nSFX S Y 1\nSFX S 0 s .\n")
+	dic := []byte("1\n\xe9quipe/S\n")
+	d, err := Parse(aff, dic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, d, []string{"équipe", "équipes", "Équipe"}, []string{"equipe"})
+
+	if _, err := Parse([]byte("SET KOI8-R\n"), []byte("0\n")); err == nil {
+		t.Error("Parse accepted an unsupported encoding")
+	}
+}
+
+func TestCompounds(t *testing.T) {
+	d := parse(t, `SET UTF-8
+COMPOUNDMIN 3
+COMPOUNDBEGIN x
+COMPOUNDMIDDLE y
+COMPOUNDEND z
+ONLYINCOMPOUND o
+
+SFX N Y 1
+SFX N 0 en .
+
+SFX F Y 1
+SFX F 0 s/xy .
+`, `4
+Software/xy
+Entwicklung/zNF
+Prozess/zN
+Fugen/o
+`)
+
+	expect(t, d,
+		[]string{"Softwareentwicklung", "Softwareentwicklungen", "Entwicklungsprozess",
+			"Softwareentwicklungsprozess", "Entwicklungsprozessen"},
+		[]string{"entwicklungsoftware", "SoftwareEntwicklung", "Prozesssoftware", "Fugen", "Softwarefugen"})
+}
+
+func TestIconvAndSuggest(t *testing.T) {
+	d := parse(t, `SET UTF-8
+TRY esianrtolcdugmphbyfvkwz'
+ICONV 1
+ICONV ’ '
+REP 1
+REP f ph
+`, `5
+the
+team
+phone
+didn't
+receive
+`)
+
+	expect(t, d, []string{"didn’t", "didn't"}, []string{"didnt"})
+
+	tests := map[string]string{
+		"teh":     "the",
+		"tema":    "team",
+		"fone":    "phone",
+		"recieve": "receive",
+		"theteam": "the team",
+	}
+	for word, want := range tests {
+		if got := d.Suggest(word); !slices.Contains(got, want) {
+			t.Errorf("Suggest(%q) = %q, want %q among them", word, got, want)
+		}
+	}
+	if got := d.Suggest("teh"); len(got) == 0 || got[0] != "the" {
+		t.Errorf("Suggest(%q) = %q, want %q first", "teh", got, "the")
+	}
+}
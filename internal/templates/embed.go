@@ -23,7 +23,7 @@ import (
 	"strings"
 )
 
-//go:embed themes/*.css base.html
+//go:embed themes/*.css base.html letter.html compact.css
 var content embed.FS
 
 // Theme represents an available theme
@@ -142,6 +142,22 @@ func GetThemeCSS(themeName string) (string, error) {
 	return string(data), nil
 }
 
+// primaryColorPattern matches the --primary-color declaration of a theme
+var primaryColorPattern = regexp.MustCompile(`--primary-color:\s*(#[0-9A-Fa-f]{6});`)
+
+// GetThemePrimaryColor returns the --primary-color declared by a theme, or
+// "" for an unknown theme
+func GetThemePrimaryColor(themeName string) string {
+	css, err := GetThemeCSS(themeName)
+	if err != nil {
+		return ""
+	}
+	if m := primaryColorPattern.FindStringSubmatch(css); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
 // GetThemeCSSWithColor returns the CSS with custom primary color
 func GetThemeCSSWithColor(themeName, customColor string) (string, error) {
 	css, err := GetThemeCSS(themeName)
@@ -169,6 +185,16 @@ func GetThemeCSSWithColor(themeName, customColor string) (string, error) {
 	return css, nil
 }
 
+// GetCompactCSS returns the rules tightening the spacing of any theme, to
+// add after the theme CSS
+func GetCompactCSS() (string, error) {
+	data, err := content.ReadFile("compact.css")
+	if err != nil {
+		return "", fmt.Errorf("error reading compact CSS: %w", err)
+	}
+	return string(data), nil
+}
+
 // GetBaseTemplate returns the base HTML template
 func GetBaseTemplate() (string, error) {
 	data, err := content.ReadFile("base.html")
@@ -220,6 +246,32 @@ func GetParsedTemplateWithColor(themeName, customColor string, funcMap template.
 	return tmpl, nil
 }
 
+// GetParsedLetterTemplateWithColor returns the parsed cover letter template
+// with the theme and custom color. It shares the "header" and "resume"
+// blocks of the CV template.
+func GetParsedLetterTemplateWithColor(themeName, customColor string, funcMap template.FuncMap) (*template.Template, error) {
+	cv, err := GetParsedTemplateWithColor(themeName, customColor, funcMap)
+	if err != nil {
+		return nil, err
+	}
+
+	letterHTML, err := content.ReadFile("letter.html")
+	if err != nil {
+		return nil, fmt.Errorf("error reading template: %w", err)
+	}
+	themeCSS, err := GetThemeCSSWithColor(themeName, customColor)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := cv.New("letter").Parse(strings.Replace(string(letterHTML), "{{THEME_CSS}}", themeCSS, 1))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
 // GetThemeNames returns theme names separated by commas
 func GetThemeNames() string {
 	names := make([]string, 0, len(AvailableThemes))
@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package vcard builds a vCard 4.0 (RFC 6350) contact card from the
+// personal information of a CV.
+package vcard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"resumectl/internal/models"
+	"resumectl/internal/privacy"
+)
+
+// Warn reports the problems that do not stop the encoding, such as a photo
+// whose metadata cannot be read. They are discarded by default.
+var Warn = func(msg string, keyvals ...any) {}
+
+// maxLineLength is the length in octets after which content lines are
+// folded
+const maxLineLength = 75
+
+// Encode returns the vCard of p. With photo, a local photo file is
+// embedded as a data URI (stripped of its location data) and a remote one
+// linked; cards meant for QR codes leave it out to stay small.
+func Encode(p models.Personal, photo bool) ([]byte, error) {
+	var card card
+	card.add("BEGIN", "VCARD")
+	card.add("VERSION", "4.0")
+	card.add("FN", escape(strings.TrimSpace(p.FullName())))
+	card.add("N", structured(p.LastName, p.FirstName, "", "", ""))
+	if p.Title != "" {
+		card.add("TITLE", escape(p.Title))
+	}
+	if p.Email != "" {
+		card.add("EMAIL", escape(p.Email))
+	}
+	if p.Phone != "" {
+		card.add("TEL;VALUE=uri", "tel:"+telURI(p.Phone))
+	}
+	if p.Location != "" {
+		card.add("ADR", structured("", "", "", p.Location, "", "", ""))
+	}
+	for _, url := range []string{p.Website, p.LinkedIn, p.GitHub} {
+		if url != "" {
+			card.add("URL", absoluteURL(url))
+		}
+	}
+
+	if photo && p.Photo != "" {
+		value, err := photoURI(p.Photo)
+		if err != nil {
+			return nil, err
+		}
+		card.add("PHOTO", value)
+	}
+
+	card.add("END", "VCARD")
+	return []byte(card.String()), nil
+}
+
+// Write writes the vCard of p, photo included, to path
+func Write(p models.Personal, path string) error {
+	data, err := Encode(p, true)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing vCard: %w", err)
+	}
+	return nil
+}
+
+// card accumulates folded content lines
+type card struct {
+	strings.Builder
+}
+
+// add appends a content line, folded at maxLineLength octets without
+// splitting UTF-8 sequences
+func (c *card) add(name, value string) {
+	line := name + ":" + value
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		c.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// The leading space counts in the length of continuation lines
+		limit = maxLineLength - 1
+	}
+	c.WriteString(line + "\r\n")
+}
+
+// escape escapes a text value
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// structured joins the components of a structured value (N, ADR)
+func structured(components ...string) string {
+	for i, c := range components {
+		components[i] = escape(c)
+	}
+	return strings.Join(components, ";")
+}
+
+// telURI keeps the digits and the leading + of a phone number, with
+// hyphens between groups ("+33 6 12 34" -> "+33-6-12-34")
+func telURI(phone string) string {
+	return strings.Join(strings.FieldsFunc(phone, func(r rune) bool {
+		return r == ' ' || r == '.' || r == '-' || r == '(' || r == ')' || r == '/'
+	}), "-")
+}
+
+// absoluteURL adds the https scheme to profile URLs written without it
+func absoluteURL(url string) string {
+	if strings.Contains(url, "://") {
+		return url
+	}
+	return "https://" + url
+}
+
+// photoURI returns the PHOTO value: remote photos as is, local ones as a
+// data URI
+func photoURI(photo string) (string, error) {
+	if strings.HasPrefix(photo, "http://") || strings.HasPrefix(photo, "https://") {
+		return photo, nil
+	}
+
+	data, err := os.ReadFile(photo)
+	if err != nil {
+		return "", fmt.Errorf("error reading photo: %w", err)
+	}
+	// Like the generator, embed a photo whose metadata cannot be read as is
+	if stripped, err := privacy.StripLocation(data); err != nil {
+		Warn("Photo metadata could not be read, location data may remain", "photo", photo, "error", err)
+	} else {
+		data = stripped
+	}
+
+	mediaType := mime.TypeByExtension(strings.ToLower(filepath.Ext(photo)))
+	if mediaType == "" {
+		mediaType = http.DetectContentType(data)
+	}
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Julien Briault
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package vcard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"resumectl/internal/models"
+)
+
+func TestEscape(t *testing.T) {
+	tests := map[string]string{
+		"Jane Doe":             "Jane Doe",
+		"Paris, France":        `Paris\, France`,
+		"R&D; Platform":        `R&D\; Platform`,
+		`C:\Users`:             `C:\\Users`,
+		"line one\nline two":   `line one\nline two`,
+		"line one\r\nline two": `line one\nline two`,
+		`a\,b`:                 `a\\\,b`,
+	}
+	for input, want := range tests {
+		if got := escape(input); got != want {
+			t.Errorf("escape(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestFolding(t *testing.T) {
+	tests := []string{
+		"short",
+		strings.Repeat("a", 73),  // 75 octets with "X:"
+		strings.Repeat("a", 74),  // One octet too long
+		strings.Repeat("é", 100), // Two-octet sequences across fold points
+		strings.Repeat("ab€", 60),
+	}
+
+	for _, value := range tests {
+		var c card
+		c.add("X", value)
+		folded := c.String()
+
+		if !strings.HasSuffix(folded, "\r\n") {
+			t.Errorf("%q: card does not end with CRLF", value)
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+			if len(line) > maxLineLength {
+				t.Errorf("%q: line of %d octets: %q", value, len(line), line)
+			}
+			if !utf8.ValidString(line) {
+				t.Errorf("%q: line splits a UTF-8 sequence: %q", value, line)
+			}
+		}
+		if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != "X:"+value+"\r\n" {
+			t.Errorf("unfolded card = %q, want %q", unfolded, "X:"+value+"\r\n")
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	p := models.Personal{
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Title:     "Engineer, Platform; SRE",
+		Email:     "jane@example.com",
+		Phone:     "+33 6 12.34-56 (78)",
+		Location:  "Paris, France",
+		Website:   "jane.example.com",
+		GitHub:    "https://github.com/jane",
+		Photo:     "https://jane.example.com/photo.jpg",
+	}
+
+	want := "BEGIN:VCARD\r\n" +
+		"VERSION:4.0\r\n" +
+		"FN:Jane Doe\r\n" +
+		"N:Doe;Jane;;;\r\n" +
+		"TITLE:Engineer\\, Platform\\; SRE\r\n" +
+		"EMAIL:jane@example.com\r\n" +
+		"TEL;VALUE=uri:tel:+33-6-12-34-56-78\r\n" +
+		"ADR:;;;Paris\\, France;;;\r\n" +
+		"URL:https://jane.example.com\r\n" +
+		"URL:https://github.com/jane\r\n" +
+		"PHOTO:https://jane.example.com/photo.jpg\r\n" +
+		"END:VCARD\r\n"
+
+	got, err := Encode(p, true)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("Encode =\n%s\nwant\n%s", got, want)
+	}
+
+	// Cards for QR codes leave the photo out
+	got, err = Encode(p, false)
+	if err != nil {
+		t.Fatalf("Encode without photo: %v", err)
+	}
+	if strings.Contains(string(got), "PHOTO") {
+		t.Errorf("Encode without photo has a PHOTO line:\n%s", got)
+	}
+}
+
+func TestEncodeUnreadablePhotoMetadata(t *testing.T) {
+	// A JPEG whose first segment is truncated: its metadata cannot be read
+	photo := filepath.Join(t.TempDir(), "photo.jpg")
+	if err := os.WriteFile(photo, []byte("\xFF\xD8\xFF\xE1\x00"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var warnings []string
+	Warn = func(msg string, keyvals ...any) { warnings = append(warnings, msg) }
+	t.Cleanup(func() { Warn = func(msg string, keyvals ...any) {} })
+
+	got, err := Encode(models.Personal{FirstName: "Jane", Photo: photo}, true)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if !strings.Contains(string(got), "PHOTO:data:image/jpeg;base64,") {
+		t.Errorf("photo not embedded:\n%s", got)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %q, want one", warnings)
+	}
+}